@@ -1,33 +1,130 @@
 package bluto
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/alibaba-go/bluto/commander"
 	"github.com/gomodule/redigo/redis"
 )
 
+const (
+	// watchMaxAttempts is the maximum number of times an aborted transaction is run by Watch
+	watchMaxAttempts = 10
+	// watchMinBackoff is the wait duration before the first retry of an aborted transaction
+	watchMinBackoff = 8 * time.Millisecond
+	// watchMaxBackoff is the maximum wait duration between the retries of an aborted transaction
+	watchMaxBackoff = 512 * time.Millisecond
+)
+
 // Bluto is a wrapper over redis pool
 type Bluto struct {
-	pool *redis.Pool
+	// mu guards the pool which is replaced after a sentinel failover
+	mu     sync.RWMutex
+	pool   *redis.Pool
+	config Config
+	// cancel stops watching the sentinels and done is closed when it is stopped
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
-// New creates new Bluto instance
+// New creates new Bluto instance, the master is discovered from the sentinels if config.SentinelAddresses is set
 func New(config Config) (*Bluto, error) {
+	if len(config.SentinelAddresses) > 0 {
+		return newSentinel(config)
+	}
 	pool, err := GetPool(config)
 	if err != nil {
 		return nil, err
 	}
-	bl := &Bluto{pool: pool}
+	bl := &Bluto{pool: pool, config: config}
 	return bl, nil
 }
 
+// getPool returns the current redis pool
+func (bl *Bluto) getPool() *redis.Pool {
+	bl.mu.RLock()
+	defer bl.mu.RUnlock()
+	return bl.pool
+}
+
+// dial dials a new connection out of the current redis pool
+func (bl *Bluto) dial() (redis.Conn, error) {
+	return bl.getPool().Dial()
+}
+
 // Borrow borrows a redis connection from pool
 func (bl *Bluto) Borrow() *commander.Commander {
-	conn := bl.pool.Get()
+	conn := bl.getPool().Get()
+	commander := commander.New(conn)
+	return commander
+}
+
+// BorrowContext borrows a redis connection from pool, it waits for a connection
+// until the context is done and the returned commander fails with ctx.Err() if it is
+func (bl *Bluto) BorrowContext(ctx context.Context) *commander.Commander {
+	// on failure the returned connection reports the error on every command
+	conn, _ := bl.getPool().GetContext(ctx)
 	commander := commander.New(conn)
 	return commander
 }
 
-// ClosePool closes redis pool
+// Watch runs fn as an optimistic transaction over the keys: the keys are watched, fn reads them
+// and queues the writes with tx.Multi and Exec. If Exec returns commander.ErrTxAborted because
+// a watched key has been changed, fn is run again after a backoff, up to 10 times or until the context is done.
+func (bl *Bluto) Watch(ctx context.Context, keys []string, fn func(tx *commander.Tx) error) error {
+	backoff := watchMinBackoff
+	for attempt := 1; ; attempt++ {
+		err := bl.watch(ctx, keys, fn)
+		if err != commander.ErrTxAborted || attempt == watchMaxAttempts {
+			return err
+		}
+		// wait before the next attempt
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > watchMaxBackoff {
+			backoff = watchMaxBackoff
+		}
+	}
+}
+
+// watch runs a single attempt of the transaction on a borrowed connection.
+func (bl *Bluto) watch(ctx context.Context, keys []string, fn func(tx *commander.Tx) error) error {
+	conn, err := bl.getPool().GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	tx := commander.NewTx(conn)
+	defer tx.Close()
+	err = tx.Watch(keys...)
+	if err != nil {
+		return err
+	}
+	return fn(tx)
+}
+
+// Subscribe subscribes to the channels over a dedicated connection, the subscription
+// lasts until the context is done or it is closed
+func (bl *Bluto) Subscribe(ctx context.Context, channels ...string) (*Subscription, error) {
+	return newSubscription(ctx, bl.dial, channels, nil)
+}
+
+// PSubscribe subscribes to the patterns over a dedicated connection, the subscription
+// lasts until the context is done or it is closed
+func (bl *Bluto) PSubscribe(ctx context.Context, patterns ...string) (*Subscription, error) {
+	return newSubscription(ctx, bl.dial, nil, patterns)
+}
+
+// ClosePool closes redis pool and stops watching the sentinels
 func (bl *Bluto) ClosePool() error {
-	return bl.pool.Close()
+	if bl.cancel != nil {
+		bl.cancel()
+		<-bl.done
+	}
+	return bl.getPool().Close()
 }
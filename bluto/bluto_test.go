@@ -1,13 +1,16 @@
 package bluto_test
 
 import (
+	"context"
 	"errors"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/alibaba-go/bluto/bluto"
+	"github.com/alibaba-go/bluto/commander"
 )
 
 var _ = Describe("Bluto", func() {
@@ -76,4 +79,281 @@ var _ = Describe("Bluto", func() {
 			Expect(pingResult).To(Equal("PONG"))
 		})
 	})
+
+	Describe("BorrowContext", func() {
+		It("should borrow a connection from the redis pool", func() {
+			bluto, newErr := bluto.New(getCorrectConfig())
+			defer bluto.ClosePool()
+			var pingResult string
+			cmdErr := bluto.BorrowContext(context.Background()).Ping(&pingResult).Commit()
+			Expect(cmdErr).To(BeNil())
+			Expect(newErr).To(BeNil())
+			Expect(pingResult).To(Equal("PONG"))
+		})
+
+		It("should fail to borrow a connection when the context is done", func() {
+			config := getCorrectConfig()
+			config.MaxActive = 1
+			bluto, newErr := bluto.New(config)
+			defer bluto.ClosePool()
+			// hold the only connection of the pool until the context is done
+			held := bluto.Borrow()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			var pingResult string
+			cmdErr := bluto.BorrowContext(ctx).Ping(&pingResult).Commit()
+			var heldPingResult string
+			heldErr := held.Ping(&heldPingResult).Commit()
+			Expect(heldErr).To(BeNil())
+			Expect(cmdErr).To(Equal(context.DeadlineExceeded))
+			Expect(newErr).To(BeNil())
+			Expect(pingResult).To(Not(Equal("PONG")))
+			Expect(heldPingResult).To(Equal("PONG"))
+		})
+	})
+
+	Describe("Watch", func() {
+		It("should retry a transaction when a watched key is changed", func() {
+			bluto, newErr := bluto.New(getCorrectConfig())
+			defer bluto.ClosePool()
+			key := "SomeKey"
+			var setResult string
+			setErr := bluto.Borrow().Set(&setResult, key, 1).Commit()
+			attempts := 0
+			watchErr := bluto.Watch(context.Background(), []string{key}, func(tx *commander.Tx) error {
+				attempts++
+				var getResult int
+				err := tx.Read().Get(&getResult, key).Commit()
+				if err != nil {
+					return err
+				}
+				// change the watched key on the first attempt
+				if attempts == 1 {
+					var otherSetResult string
+					err = bluto.Borrow().Set(&otherSetResult, key, 10).Commit()
+					if err != nil {
+						return err
+					}
+				}
+				var txSetResult string
+				return tx.Multi().Set(&txSetResult, key, getResult*2).Exec()
+			})
+			var getResult int
+			getErr := bluto.Borrow().Get(&getResult, key).Commit()
+			Expect(newErr).To(BeNil())
+			Expect(setErr).To(BeNil())
+			Expect(watchErr).To(BeNil())
+			Expect(getErr).To(BeNil())
+			Expect(attempts).To(Equal(2))
+			Expect(getResult).To(Equal(20))
+		})
+	})
+
+	Describe("Subscribe", func() {
+		// publish publishes the message until it is received by a subscriber
+		var publish = func(bluto *bluto.Bluto, channel, message string) error {
+			for {
+				var publishResult int
+				err := bluto.Borrow().Publish(&publishResult, channel, message).Commit()
+				if err != nil || publishResult > 0 {
+					return err
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+
+		It("should receive the messages of the subscribed channels", func() {
+			bluto, newErr := bluto.New(getCorrectConfig())
+			defer bluto.ClosePool()
+			subscription, subErr := bluto.Subscribe(context.Background(), "SomeChannel")
+			pubErr := publish(bluto, "SomeChannel", "SomeMessage")
+			message := <-subscription.Messages()
+			closeErr := subscription.Close()
+			_, open := <-subscription.Messages()
+
+			Expect(newErr).To(BeNil())
+			Expect(subErr).To(BeNil())
+			Expect(pubErr).To(BeNil())
+			Expect(closeErr).To(BeNil())
+			Expect(message.Channel).To(Equal("SomeChannel"))
+			Expect(message.Pattern).To(Equal(""))
+			Expect(string(message.Data)).To(Equal("SomeMessage"))
+			Expect(open).To(BeFalse())
+		})
+
+		It("should receive the messages of the subscribed patterns", func() {
+			bluto, newErr := bluto.New(getCorrectConfig())
+			defer bluto.ClosePool()
+			ctx, cancel := context.WithCancel(context.Background())
+			subscription, subErr := bluto.PSubscribe(ctx, "Some*")
+			pubErr := publish(bluto, "SomeChannel", "SomeMessage")
+			message := <-subscription.Messages()
+			cancel()
+			_, open := <-subscription.Messages()
+
+			Expect(newErr).To(BeNil())
+			Expect(subErr).To(BeNil())
+			Expect(pubErr).To(BeNil())
+			Expect(message.Channel).To(Equal("SomeChannel"))
+			Expect(message.Pattern).To(Equal("Some*"))
+			Expect(string(message.Data)).To(Equal("SomeMessage"))
+			Expect(open).To(BeFalse())
+		})
+
+		It("should fail to subscribe with wrong config", func() {
+			bluto, newErr := bluto.New(getWrongConfig())
+			defer bluto.ClosePool()
+			subscription, subErr := bluto.Subscribe(context.Background(), "SomeChannel")
+
+			Expect(newErr).To(BeNil())
+			Expect(subErr).To(Not(BeNil()))
+			Expect(subscription).To(BeNil())
+		})
+	})
+
+	Describe("Cluster", func() {
+		var getClusterConfig = func() bluto.Config {
+			address := os.Getenv("REDIS_CLUSTER_ADDRESS")
+			if address == "" {
+				Skip("REDIS_CLUSTER_ADDRESS is not set")
+			}
+			return bluto.Config{
+				ClusterAddresses: []string{address},
+			}
+		}
+
+		It("should route the commands to the nodes of the cluster", func() {
+			cluster, newErr := bluto.NewCluster(getClusterConfig())
+			defer cluster.ClosePool()
+			var setResult1 string
+			var setResult2 string
+			var getResult1 string
+			var getResult2 string
+			cmdErr := cluster.Borrow().
+				Set(&setResult1, "{user1}.name", "SomeName").
+				Set(&setResult2, "{user2}.name", "OtherName").
+				Get(&getResult1, "{user1}.name").
+				Get(&getResult2, "{user2}.name").
+				Commit()
+			Expect(newErr).To(BeNil())
+			Expect(cmdErr).To(BeNil())
+			Expect(setResult1).To(Equal("OK"))
+			Expect(setResult2).To(Equal("OK"))
+			Expect(getResult1).To(Equal("SomeName"))
+			Expect(getResult2).To(Equal("OtherName"))
+		})
+
+		It("should fail to create new cluster instance with wrong config", func() {
+			cluster, newErr := bluto.NewCluster(getWrongConfig())
+			Expect(newErr).To(Not(BeNil()))
+			Expect(cluster).To(BeNil())
+		})
+	})
+
+	Describe("Sentinel", func() {
+		var getSentinelConfig = func() bluto.Config {
+			address := os.Getenv("REDIS_SENTINEL_ADDRESS")
+			if address == "" {
+				Skip("REDIS_SENTINEL_ADDRESS is not set")
+			}
+			return bluto.Config{
+				SentinelAddresses:  []string{address},
+				SentinelMasterName: os.Getenv("REDIS_SENTINEL_MASTER"),
+			}
+		}
+
+		It("should borrow a connection from the pool of the sentinel master", func() {
+			bluto, newErr := bluto.New(getSentinelConfig())
+			defer bluto.ClosePool()
+			var pingResult string
+			cmdErr := bluto.Borrow().Ping(&pingResult).Commit()
+			Expect(newErr).To(BeNil())
+			Expect(cmdErr).To(BeNil())
+			Expect(pingResult).To(Equal("PONG"))
+		})
+
+		It("should fail to create new bluto instance with wrong sentinel config", func() {
+			config := getWrongConfig()
+			config.SentinelAddresses = []string{config.Address}
+			config.SentinelMasterName = "mymaster"
+			bluto, newErr := bluto.New(config)
+			Expect(newErr).To(Not(BeNil()))
+			Expect(bluto).To(BeNil())
+		})
+	})
+
+	Describe("Scan", func() {
+		It("should iterate over the keys and the elements of a key", func() {
+			bl, newErr := bluto.New(getCorrectConfig())
+			defer bl.ClosePool()
+			var flushResult string
+			var setResult string
+			var hSetResult int
+			var sAddResult int
+			var zAddResult int
+			cmdErr := bl.Borrow().
+				FlushAll(&flushResult).
+				Set(&setResult, "ScanKey1", "SomeValue").
+				Set(&setResult, "ScanKey2", "SomeValue").
+				Set(&setResult, "OtherKey", "SomeValue").
+				HSet(&hSetResult, "ScanHash", []string{"field1", "field2"}, []interface{}{"value1", "value2"}).
+				SAdd(&sAddResult, "ScanSet", "a", "b", "c").
+				ZAdd(&zAddResult, "ScanZSet", []float64{1}, []interface{}{"a"}).
+				Commit()
+
+			var keys []string
+			keysIt := bl.Scan(context.Background(), bluto.ScanOptions{Match: "ScanKey*", Count: 1})
+			for keysIt.Next() {
+				keys = append(keys, keysIt.Batch()...)
+			}
+			var typeKeys []string
+			typeIt := bl.Scan(context.Background(), bluto.ScanOptions{Type: "hash"})
+			for typeIt.Next() {
+				typeKeys = append(typeKeys, typeIt.Batch()...)
+			}
+			var fields []string
+			hIt := bl.HScan(context.Background(), "ScanHash", bluto.ScanOptions{})
+			for hIt.Next() {
+				fields = append(fields, hIt.Batch()...)
+			}
+			var members []string
+			sIt := bl.SScan(context.Background(), "ScanSet", bluto.ScanOptions{Match: "[ab]"})
+			for sIt.Next() {
+				members = append(members, sIt.Batch()...)
+			}
+			var zMembers []string
+			zIt := bl.ZScan(context.Background(), "ScanZSet", bluto.ScanOptions{})
+			for zIt.Next() {
+				zMembers = append(zMembers, zIt.Batch()...)
+			}
+			// the keys are removed so that they are not seen by the other tests
+			flushErr := bl.Borrow().FlushAll(&flushResult).Commit()
+
+			Expect(newErr).To(BeNil())
+			Expect(cmdErr).To(BeNil())
+			Expect(flushErr).To(BeNil())
+			Expect(keysIt.Err()).To(BeNil())
+			Expect(keys).To(ConsistOf("ScanKey1", "ScanKey2"))
+			Expect(typeIt.Err()).To(BeNil())
+			Expect(typeKeys).To(Equal([]string{"ScanHash"}))
+			Expect(hIt.Err()).To(BeNil())
+			Expect(fields).To(Equal([]string{"field1", "value1", "field2", "value2"}))
+			Expect(sIt.Err()).To(BeNil())
+			Expect(members).To(ConsistOf("a", "b"))
+			Expect(zIt.Err()).To(BeNil())
+			Expect(zMembers).To(Equal([]string{"a", "1"}))
+		})
+
+		It("should stop the iteration when the context is canceled", func() {
+			bl, newErr := bluto.New(getCorrectConfig())
+			defer bl.ClosePool()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			it := bl.Scan(ctx, bluto.ScanOptions{})
+
+			Expect(newErr).To(BeNil())
+			Expect(it.Next()).To(BeFalse())
+			Expect(it.Err()).To(Equal(context.Canceled))
+		})
+	})
 })
@@ -0,0 +1,681 @@
+package bluto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alibaba-go/bluto/commander"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// clusterSlots is the number of hash slots of a redis cluster
+	clusterSlots = 16384
+	// clusterMaxRedirects is the maximum number of MOVED and ASK redirections which are followed for a command
+	clusterMaxRedirects = 5
+)
+
+// errClusterTx is returned for the transaction commands which can not be routed to a single node
+var errClusterTx = errors.New("bluto: transactions are not supported in cluster mode")
+
+// errClusterNoNode is returned when the slots of the cluster have no node
+var errClusterNoNode = errors.New("bluto: no cluster node is known")
+
+// clusterFanOut is the commands without a key which are run on all the master nodes,
+// their replies are merged into the reply of the whole cluster
+var clusterFanOut = map[string]bool{
+	"KEYS":      true,
+	"DBSIZE":    true,
+	"RANDOMKEY": true,
+	"FLUSHALL":  true,
+	"FLUSHDB":   true,
+}
+
+// Cluster is a wrapper over the redis pools of the nodes of a redis cluster,
+// each command is routed to the node which serves the hash slot of its key
+type Cluster struct {
+	config Config
+	// getConn returns a connection to the node at the address
+	getConn func(ctx context.Context, address string) (redis.Conn, error)
+	// refreshing is set while the topology is being refreshed after a MOVED redirection
+	refreshing int32
+
+	mu    sync.RWMutex
+	pools map[string]*redis.Pool
+	slots []string
+}
+
+// NewCluster creates new Cluster instance, the slots of the cluster are discovered
+// from the nodes of config.ClusterAddresses or config.Address if it is empty.
+// Database and the sentinel options are rejected since the cluster nodes do not support them.
+func NewCluster(config Config) (*Cluster, error) {
+	// the nodes of a cluster only have the database 0 and are not monitored by sentinels
+	if config.Database != 0 {
+		return nil, errors.New("bluto: Database is not supported in cluster mode")
+	}
+	if config.SentinelMasterName != "" || len(config.SentinelAddresses) > 0 {
+		return nil, errors.New("bluto: sentinel options are not supported in cluster mode")
+	}
+	cl := &Cluster{
+		config: config,
+		pools:  make(map[string]*redis.Pool),
+		slots:  make([]string, clusterSlots),
+	}
+	cl.getConn = cl.poolConn
+	err := cl.Refresh()
+	if err != nil {
+		cl.ClosePool()
+		return nil, err
+	}
+	return cl, nil
+}
+
+// Borrow borrows a cluster connection which routes the commands to the connections of the nodes
+func (cl *Cluster) Borrow() *commander.Commander {
+	return cl.BorrowContext(context.Background())
+}
+
+// BorrowContext borrows a cluster connection which waits for the connections of the nodes until the context is done
+func (cl *Cluster) BorrowContext(ctx context.Context) *commander.Commander {
+	conn := &clusterConn{
+		cluster: cl,
+		ctx:     ctx,
+		conns:   make(map[string]redis.Conn),
+	}
+	commander := commander.New(conn)
+	return commander
+}
+
+// Refresh discovers the slots of the cluster with CLUSTER SLOTS from the first node which replies
+func (cl *Cluster) Refresh() error {
+	seeds := cl.config.ClusterAddresses
+	if len(seeds) == 0 {
+		seeds = []string{cl.config.Address}
+	}
+	// the known nodes are tried before the seeds
+	cl.mu.RLock()
+	addresses := make([]string, 0, len(cl.pools)+len(seeds))
+	for address := range cl.pools {
+		addresses = append(addresses, address)
+	}
+	cl.mu.RUnlock()
+	addresses = append(addresses, seeds...)
+
+	err := errors.New("bluto: no cluster address")
+	for _, address := range addresses {
+		var slots []string
+		slots, err = cl.clusterSlots(address)
+		if err == nil {
+			cl.setSlots(slots)
+			return nil
+		}
+	}
+	return err
+}
+
+// ClosePool closes the redis pools of all the nodes
+func (cl *Cluster) ClosePool() error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	var err error
+	for address, pool := range cl.pools {
+		closeErr := pool.Close()
+		if err == nil {
+			err = closeErr
+		}
+		delete(cl.pools, address)
+	}
+	return err
+}
+
+// clusterSlots returns the address of the master node of each slot from the node at the address
+func (cl *Cluster) clusterSlots(address string) ([]string, error) {
+	conn, err := cl.getConn(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ranges, err := redis.Values(conn.Do("CLUSTER", "SLOTS"))
+	if err != nil {
+		return nil, err
+	}
+	// an empty host means the host of the node which has replied
+	seedHost, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]string, clusterSlots)
+	for _, slotRange := range ranges {
+		// each range has at least three parts: 1-start, 2-end, 3-master, and then the replicas
+		var start, end int
+		var master []interface{}
+		values, err := redis.Values(slotRange, nil)
+		if err == nil {
+			_, err = redis.Scan(values, &start, &end, &master)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var host string
+		var port int
+		_, err = redis.Scan(master, &host, &port)
+		if err != nil {
+			return nil, err
+		}
+		if host == "" {
+			host = seedHost
+		}
+		if start < 0 || end >= clusterSlots || start > end {
+			return nil, fmt.Errorf("bluto: invalid cluster slot range %d-%d", start, end)
+		}
+		for slot := start; slot <= end; slot++ {
+			slots[slot] = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+	}
+	return slots, nil
+}
+
+// setSlots replaces the slots of the cluster and closes the pools of the removed nodes
+func (cl *Cluster) setSlots(slots []string) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.slots = slots
+	nodes := make(map[string]bool)
+	for _, address := range slots {
+		nodes[address] = true
+	}
+	for address, pool := range cl.pools {
+		if !nodes[address] {
+			pool.Close()
+			delete(cl.pools, address)
+		}
+	}
+}
+
+// moved updates the node of the slot after a MOVED redirection and refreshes the slots of the cluster in background
+func (cl *Cluster) moved(slot int, address string) {
+	cl.mu.Lock()
+	if slot >= 0 && slot < clusterSlots {
+		cl.slots[slot] = address
+	}
+	cl.mu.Unlock()
+	if atomic.CompareAndSwapInt32(&cl.refreshing, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&cl.refreshing, 0)
+			cl.Refresh()
+		}()
+	}
+}
+
+// poolConn borrows a connection from the pool of the node at the address
+func (cl *Cluster) poolConn(ctx context.Context, address string) (redis.Conn, error) {
+	cl.mu.Lock()
+	pool, ok := cl.pools[address]
+	if !ok {
+		config := cl.config
+		config.Address = address
+		newPool, err := GetPool(config)
+		if err != nil {
+			cl.mu.Unlock()
+			return nil, err
+		}
+		pool = newPool
+		cl.pools[address] = pool
+	}
+	cl.mu.Unlock()
+	return pool.GetContext(ctx)
+}
+
+// address returns the address of the node which the command is routed to
+func (cl *Cluster) address(name string, args []interface{}) (string, error) {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	key, ok, err := commandKey(name, args)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		if address := cl.slots[Slot(key)]; address != "" {
+			return address, nil
+		}
+	}
+	// the commands without a key are run on any node
+	for _, address := range cl.slots {
+		if address != "" {
+			return address, nil
+		}
+	}
+	return "", errClusterNoNode
+}
+
+// nodes returns the addresses of the master nodes which serve the slots
+func (cl *Cluster) nodes() []string {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, address := range cl.slots {
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// commandKey returns the key which is used to route the command
+func commandKey(name string, args []interface{}) (string, bool, error) {
+	position := 0
+	switch strings.ToUpper(name) {
+	case "MULTI", "EXEC", "DISCARD", "WATCH", "UNWATCH":
+		return "", false, errClusterTx
+	case "PING", "ECHO", "SELECT", "SCRIPT", "INFO":
+		return "", false, nil
+	case "EVAL", "EVALSHA":
+		// the keys come after the number of keys
+		if len(args) < 3 || argString(args[1]) == "0" {
+			return "", false, nil
+		}
+		position = 2
+	case "SINTERCARD", "ZUNION", "ZINTER", "ZDIFF", "ZINTERCARD":
+		// the keys come after the number of keys
+		position = 1
+	case "BLMPOP":
+		// the keys come after the timeout and the number of keys
+		position = 2
+	case "XREAD", "XREADGROUP":
+		// the streams come after STREAMS
+		position = -1
+		for i := range args {
+			if strings.ToUpper(argString(args[i])) == "STREAMS" {
+				position = i + 1
+				break
+			}
+		}
+	case "XGROUP", "XINFO", "OBJECT", "BITOP":
+		// the key comes after the subcommand
+		position = 1
+	}
+	if position < 0 || position >= len(args) {
+		return "", false, nil
+	}
+	return argString(args[position]), true, nil
+}
+
+// argString returns the string of a command argument
+func argString(arg interface{}) string {
+	switch arg := arg.(type) {
+	case string:
+		return arg
+	case []byte:
+		return string(arg)
+	default:
+		return fmt.Sprint(arg)
+	}
+}
+
+// Slot returns the cluster hash slot of the key, only the hash tag of the key
+// which is the part between the first { and the next } is hashed if it is not empty
+func Slot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % clusterSlots
+}
+
+// crc16 returns the CRC16 XMODEM checksum of the key which is used by redis cluster
+func crc16(key string) uint16 {
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// parseRedirect parses a MOVED or ASK error reply e.g. MOVED 3999 127.0.0.1:6381
+func parseRedirect(reply string) (kind string, slot int, address string, ok bool) {
+	parts := strings.Fields(reply)
+	if len(parts) != 3 || (parts[0] != "MOVED" && parts[0] != "ASK") {
+		return "", 0, "", false
+	}
+	slot, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, "", false
+	}
+	return parts[0], slot, parts[2], true
+}
+
+// clusterCommand is a command which is sent to a node
+type clusterCommand struct {
+	address string
+	name    string
+	args    []interface{}
+	// fanOut is the addresses of all the nodes which the command is sent to
+	fanOut []string
+}
+
+// clusterConn is a redis connection which routes the commands to the connections of the cluster nodes
+type clusterConn struct {
+	cluster *Cluster
+	ctx     context.Context
+	conns   map[string]redis.Conn
+	pending []clusterCommand
+	// readTimeout is the read timeout of the replies which is set by DoWithTimeout for the blocking commands
+	readTimeout     time.Duration
+	withReadTimeout bool
+	// readCtx is the context of the replies which is set by DoContext
+	readCtx context.Context
+}
+
+// conn returns the connection to the node at the address, the connection is borrowed on the first use
+func (cc *clusterConn) conn(address string) (redis.Conn, error) {
+	if conn, ok := cc.conns[address]; ok {
+		return conn, nil
+	}
+	conn, err := cc.cluster.getConn(cc.ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	cc.conns[address] = conn
+	return conn, nil
+}
+
+// Close returns the connections of the nodes to their pools.
+func (cc *clusterConn) Close() error {
+	var err error
+	for address, conn := range cc.conns {
+		closeErr := conn.Close()
+		if err == nil {
+			err = closeErr
+		}
+		delete(cc.conns, address)
+	}
+	return err
+}
+
+// Err returns the error of the first broken connection of the nodes.
+func (cc *clusterConn) Err() error {
+	for _, conn := range cc.conns {
+		if err := conn.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send routes the command to the node which serves its key and writes it to the output buffer.
+func (cc *clusterConn) Send(commandName string, args ...interface{}) error {
+	if clusterFanOut[strings.ToUpper(commandName)] {
+		return cc.sendAll(commandName, args)
+	}
+	address, err := cc.cluster.address(commandName, args)
+	if err != nil {
+		return err
+	}
+	conn, err := cc.conn(address)
+	if err != nil {
+		return err
+	}
+	err = conn.Send(commandName, args...)
+	if err != nil {
+		return err
+	}
+	cc.pending = append(cc.pending, clusterCommand{
+		address: address,
+		name:    commandName,
+		args:    args,
+	})
+	return nil
+}
+
+// sendAll writes the command to the output buffers of all the master nodes
+func (cc *clusterConn) sendAll(commandName string, args []interface{}) error {
+	addresses := cc.cluster.nodes()
+	if len(addresses) == 0 {
+		return errClusterNoNode
+	}
+	for _, address := range addresses {
+		conn, err := cc.conn(address)
+		if err != nil {
+			return err
+		}
+		err = conn.Send(commandName, args...)
+		if err != nil {
+			return err
+		}
+	}
+	cc.pending = append(cc.pending, clusterCommand{
+		name:   commandName,
+		args:   args,
+		fanOut: addresses,
+	})
+	return nil
+}
+
+// Flush flushes the output buffers of the connections of the nodes.
+func (cc *clusterConn) Flush() error {
+	for _, conn := range cc.conns {
+		if err := conn.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Receive is not supported since the replies are only received in order of the commands by Do.
+func (cc *clusterConn) Receive() (interface{}, error) {
+	return nil, errors.New("bluto: Receive is not supported in cluster mode")
+}
+
+// Do sends the command and returns its reply, if the command name is empty it returns
+// the replies of all the pending commands in order like the pipelines of redigo.
+func (cc *clusterConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	if commandName != "" {
+		err := cc.Send(commandName, args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	replies, err := cc.receive()
+	if commandName == "" || err != nil {
+		return replies, err
+	}
+	reply := replies[len(replies)-1]
+	if replyErr, ok := reply.(redis.Error); ok {
+		return nil, replyErr
+	}
+	return reply, nil
+}
+
+// DoWithTimeout is like Do with the read timeout of the replies, zero timeout means no read deadline.
+func (cc *clusterConn) DoWithTimeout(timeout time.Duration, commandName string, args ...interface{}) (interface{}, error) {
+	cc.readTimeout = timeout
+	cc.withReadTimeout = true
+	defer func() {
+		cc.withReadTimeout = false
+	}()
+	return cc.Do(commandName, args...)
+}
+
+// ReceiveWithTimeout is not supported since the replies are only received in order of the commands by Do.
+func (cc *clusterConn) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	return cc.Receive()
+}
+
+// DoContext is like Do, the replies of the nodes are read with ReceiveContext of their connections.
+func (cc *clusterConn) DoContext(ctx context.Context, commandName string, args ...interface{}) (interface{}, error) {
+	cc.readCtx = ctx
+	defer func() {
+		cc.readCtx = nil
+	}()
+	return cc.Do(commandName, args...)
+}
+
+// ReceiveContext is not supported since the replies are only received in order of the commands by Do.
+func (cc *clusterConn) ReceiveContext(ctx context.Context) (interface{}, error) {
+	return cc.Receive()
+}
+
+// receiveReply receives the next reply of the connection of a node with the context of DoContext
+// or the read timeout of DoWithTimeout
+func (cc *clusterConn) receiveReply(conn redis.Conn) (interface{}, error) {
+	if cwc, ok := conn.(redis.ConnWithContext); ok && cc.readCtx != nil {
+		return cwc.ReceiveContext(cc.readCtx)
+	}
+	if cc.withReadTimeout {
+		return redis.ReceiveWithTimeout(conn, cc.readTimeout)
+	}
+	return conn.Receive()
+}
+
+// receive returns the replies of the pending commands and follows their redirections
+func (cc *clusterConn) receive() ([]interface{}, error) {
+	pending := cc.pending
+	cc.pending = nil
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	err := cc.Flush()
+	if err != nil {
+		return nil, err
+	}
+	// the replies of each node are in order of its commands
+	replies := make([]interface{}, len(pending))
+	for i, command := range pending {
+		if len(command.fanOut) > 0 {
+			replies[i], err = cc.receiveAll(command)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		reply, err := cc.receiveReply(cc.conns[command.address])
+		if replyErr, ok := err.(redis.Error); ok {
+			reply = replyErr
+		} else if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	for i, command := range pending {
+		replies[i], err = cc.redirect(command, replies[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return replies, nil
+}
+
+// receiveAll receives the replies of all the nodes of a fan-out command and merges them
+func (cc *clusterConn) receiveAll(command clusterCommand) (interface{}, error) {
+	replies := make([]interface{}, len(command.fanOut))
+	for i, address := range command.fanOut {
+		reply, err := cc.receiveReply(cc.conns[address])
+		if replyErr, ok := err.(redis.Error); ok {
+			reply = replyErr
+		} else if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return mergeReplies(command.name, replies), nil
+}
+
+// mergeReplies merges the replies of the nodes: KEYS returns the keys of all the nodes,
+// DBSIZE returns the sum of the sizes, RANDOMKEY returns the key of the first node which has one
+// and the others return the first reply, an error reply of any node is returned as is
+func mergeReplies(commandName string, replies []interface{}) interface{} {
+	for _, reply := range replies {
+		if replyErr, ok := reply.(redis.Error); ok {
+			return replyErr
+		}
+	}
+	switch strings.ToUpper(commandName) {
+	case "KEYS":
+		keys := []interface{}{}
+		for _, reply := range replies {
+			values, _ := reply.([]interface{})
+			keys = append(keys, values...)
+		}
+		return keys
+	case "DBSIZE":
+		var size int64
+		for _, reply := range replies {
+			n, _ := reply.(int64)
+			size += n
+		}
+		return size
+	case "RANDOMKEY":
+		for _, reply := range replies {
+			if reply != nil {
+				return reply
+			}
+		}
+		return nil
+	}
+	return replies[0]
+}
+
+// redirect runs the command again on the node of a MOVED or ASK reply and returns the new reply
+func (cc *clusterConn) redirect(command clusterCommand, reply interface{}) (interface{}, error) {
+	for redirects := 0; redirects < clusterMaxRedirects; redirects++ {
+		replyErr, ok := reply.(redis.Error)
+		if !ok {
+			return reply, nil
+		}
+		kind, slot, address, ok := parseRedirect(string(replyErr))
+		if !ok {
+			return reply, nil
+		}
+		conn, err := cc.conn(address)
+		if err != nil {
+			return nil, err
+		}
+		if kind == "MOVED" {
+			cc.cluster.moved(slot, address)
+		} else {
+			// the node only serves the migrating slot for the next command after ASKING
+			err = conn.Send("ASKING")
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = conn.Send(command.name, command.args...)
+		if err != nil {
+			return nil, err
+		}
+		err = conn.Flush()
+		if err != nil {
+			return nil, err
+		}
+		if kind == "ASK" {
+			_, err = cc.receiveReply(conn)
+			if err != nil {
+				return nil, err
+			}
+		}
+		reply, err = cc.receiveReply(conn)
+		if replyErr, ok := err.(redis.Error); ok {
+			reply = replyErr
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return reply, nil
+}
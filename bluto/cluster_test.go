@@ -0,0 +1,256 @@
+package bluto
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibaba-go/bluto/commander"
+	"github.com/gomodule/redigo/redis"
+	"github.com/rafaeljusto/redigomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockCluster returns a cluster whose slots are all served by the first address
+// and whose connections are created by the given function
+func newMockCluster(address string, getConn func(address string) *redigomock.Conn) *Cluster {
+	cl := &Cluster{
+		config: Config{Address: address},
+		pools:  make(map[string]*redis.Pool),
+		slots:  make([]string, clusterSlots),
+	}
+	for slot := range cl.slots {
+		cl.slots[slot] = address
+	}
+	cl.getConn = func(ctx context.Context, address string) (redis.Conn, error) {
+		return getConn(address), nil
+	}
+	return cl
+}
+
+func TestSlot(t *testing.T) {
+	assert.Equal(t, crc16("123456789"), uint16(0x31C3))
+	assert.Equal(t, Slot("foo"), 12182)
+	assert.Equal(t, Slot("{user1000}.following"), Slot("{user1000}.followers"))
+	assert.Equal(t, Slot("{user1000}.following"), Slot("user1000"))
+	assert.Equal(t, Slot("foo{}{bar}"), int(crc16("foo{}{bar}"))%clusterSlots)
+	assert.NotEqual(t, Slot("foo{}{bar}"), Slot("bar"))
+	assert.Equal(t, Slot("foo{{bar}}zap"), Slot("{bar"))
+}
+
+func TestNewClusterInvalidConfig(t *testing.T) {
+	_, err := NewCluster(Config{Address: "127.0.0.1:7000", Database: 1})
+	assert.EqualError(t, err, "bluto: Database is not supported in cluster mode")
+
+	_, err = NewCluster(Config{Address: "127.0.0.1:7000", SentinelMasterName: "mymaster"})
+	assert.EqualError(t, err, "bluto: sentinel options are not supported in cluster mode")
+}
+
+func TestCommandKey(t *testing.T) {
+	key, ok, err := commandKey("GET", []interface{}{"SomeKey"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, key, "SomeKey")
+
+	key, ok, err = commandKey("EVALSHA", []interface{}{"sha", 1, "SomeKey", "arg"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, key, "SomeKey")
+
+	key, ok, err = commandKey("XREAD", []interface{}{"COUNT", 1, "STREAMS", "SomeStream", "0"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, key, "SomeStream")
+
+	key, ok, err = commandKey("BLMPOP", []interface{}{1.5, 1, "SomeList", "LEFT"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, key, "SomeList")
+
+	key, ok, err = commandKey("SINTERCARD", []interface{}{2, "SomeSet", "OtherSet"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, key, "SomeSet")
+
+	key, ok, err = commandKey("ZUNION", []interface{}{2, "SomeZSet", "OtherZSet", "WITHSCORES"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, key, "SomeZSet")
+
+	_, ok, err = commandKey("PING", nil)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = commandKey("MULTI", nil)
+	assert.Equal(t, err, errClusterTx)
+}
+
+func TestParseRedirect(t *testing.T) {
+	kind, slot, address, ok := parseRedirect("MOVED 3999 127.0.0.1:6381")
+	assert.True(t, ok)
+	assert.Equal(t, kind, "MOVED")
+	assert.Equal(t, slot, 3999)
+	assert.Equal(t, address, "127.0.0.1:6381")
+
+	_, _, _, ok = parseRedirect("WRONGTYPE Operation against a key holding the wrong kind of value")
+	assert.False(t, ok)
+}
+
+func TestClusterMoved(t *testing.T) {
+	key := "SomeKey"
+	moved := redis.Error("MOVED 770 node2:6379")
+	cl := newMockCluster("node1:6379", func(address string) *redigomock.Conn {
+		conn := redigomock.NewConn()
+		if address == "node1:6379" {
+			conn.Command("GET", key).Expect(moved)
+			conn.Command("PING").Expect("PONG")
+		} else {
+			conn.Command("GET", key).Expect("SomeValue")
+		}
+		conn.Command("CLUSTER", "SLOTS").ExpectSlice(
+			[]interface{}{int64(0), int64(clusterSlots - 1), []interface{}{[]byte("node2"), int64(6379)}},
+		)
+		return conn
+	})
+	var pingResult string
+	var getResult string
+	errCmd := cl.Borrow().
+		Ping(&pingResult).
+		Get(&getResult, key).
+		Commit()
+
+	assert.Nil(t, errCmd)
+	assert.Equal(t, Slot(key), 770)
+	assert.Equal(t, pingResult, "PONG")
+	assert.Equal(t, getResult, "SomeValue")
+	assert.Eventually(t, func() bool {
+		cl.mu.RLock()
+		defer cl.mu.RUnlock()
+		return cl.slots[0] == "node2:6379"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClusterAsk(t *testing.T) {
+	key := "SomeKey"
+	var asking *redigomock.Cmd
+	cl := newMockCluster("node1:6379", func(address string) *redigomock.Conn {
+		conn := redigomock.NewConn()
+		if address == "node1:6379" {
+			conn.Command("GET", key).Expect(redis.Error("ASK 770 node2:6379"))
+		} else {
+			asking = conn.Command("ASKING").Expect("OK")
+			conn.Command("GET", key).Expect("SomeValue")
+		}
+		return conn
+	})
+	var getResult string
+	errCmd := cl.Borrow().
+		Get(&getResult, key).
+		Commit()
+
+	assert.Nil(t, errCmd)
+	assert.Equal(t, getResult, "SomeValue")
+	assert.True(t, asking.Called)
+	assert.Equal(t, cl.slots[Slot(key)], "node1:6379")
+}
+
+func TestClusterTransaction(t *testing.T) {
+	cl := newMockCluster("node1:6379", func(address string) *redigomock.Conn {
+		return redigomock.NewConn()
+	})
+	var incrResult int64
+	errCmd := cl.Borrow().
+		Multi().
+		Incr(&incrResult, "SomeKey").
+		Exec()
+
+	assert.Equal(t, errCmd, errClusterTx)
+}
+
+// the blocking commands extend the read deadline of the cluster connection
+var _ redis.ConnWithTimeout = (*clusterConn)(nil)
+var _ redis.ConnWithContext = (*clusterConn)(nil)
+
+func TestClusterBlockingCommand(t *testing.T) {
+	key := "SomeList"
+	cl := newMockCluster("node1:6379", func(address string) *redigomock.Conn {
+		conn := redigomock.NewConn()
+		if address == "node1:6379" {
+			conn.Command("BLPOP", key, float64(10)).Expect(redis.Error("MOVED 770 node2:6379"))
+		} else {
+			conn.Command("BLPOP", key, float64(10)).ExpectStringSlice(key, "SomeElement")
+		}
+		conn.Command("CLUSTER", "SLOTS").ExpectSlice(
+			[]interface{}{int64(0), int64(clusterSlots - 1), []interface{}{[]byte("node2"), int64(6379)}},
+		)
+		return conn
+	})
+	var blpopResult commander.ListElement
+	errCmd := cl.Borrow().
+		BLPop(&blpopResult, 10*time.Second, key).
+		Commit()
+
+	assert.Nil(t, errCmd)
+	assert.Equal(t, blpopResult, commander.ListElement{Key: key, Element: "SomeElement"})
+}
+
+func TestClusterFanOut(t *testing.T) {
+	conns := make(map[string]*redigomock.Conn)
+	cl := newMockCluster("node1:6379", func(address string) *redigomock.Conn {
+		conn := redigomock.NewConn()
+		if address == "node1:6379" {
+			conn.Command("KEYS", "Some*").ExpectStringSlice("SomeKey")
+			conn.Command("DBSIZE").Expect(int64(1))
+			conn.Command("GET", "SomeKey").Expect("SomeValue")
+		} else {
+			conn.Command("KEYS", "Some*").ExpectStringSlice("SomeOtherKey", "SomeThirdKey")
+			conn.Command("DBSIZE").Expect(int64(2))
+		}
+		conn.Command("FLUSHALL").Expect("OK")
+		conns[address] = conn
+		return conn
+	})
+	for slot := clusterSlots / 2; slot < clusterSlots; slot++ {
+		cl.slots[slot] = "node2:6379"
+	}
+	var keysResult []string
+	var dbsizeResult int64
+	var getResult string
+	var flushAllResult string
+	errCmd := cl.Borrow().
+		Keys(&keysResult, "Some*").
+		Command(&dbsizeResult, "DBSIZE").
+		Get(&getResult, "SomeKey").
+		FlushAll(&flushAllResult).
+		Commit()
+
+	assert.Nil(t, errCmd)
+	assert.Equal(t, keysResult, []string{"SomeKey", "SomeOtherKey", "SomeThirdKey"})
+	assert.Equal(t, dbsizeResult, int64(3))
+	assert.Equal(t, getResult, "SomeValue")
+	assert.Equal(t, flushAllResult, "OK")
+	for _, conn := range conns {
+		assert.Nil(t, conn.ExpectationsWereMet())
+	}
+}
+
+func TestClusterFanOutError(t *testing.T) {
+	cl := newMockCluster("node1:6379", func(address string) *redigomock.Conn {
+		conn := redigomock.NewConn()
+		if address == "node1:6379" {
+			conn.Command("DBSIZE").Expect(int64(1))
+		} else {
+			conn.Command("DBSIZE").Expect(redis.Error("LOADING Redis is loading the dataset in memory"))
+		}
+		return conn
+	})
+	for slot := clusterSlots / 2; slot < clusterSlots; slot++ {
+		cl.slots[slot] = "node2:6379"
+	}
+	var dbsizeResult int64
+	errCmd := cl.Borrow().
+		Command(&dbsizeResult, "DBSIZE").
+		Commit()
+
+	assert.Contains(t, errCmd.Error(), "LOADING")
+}
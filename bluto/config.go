@@ -1,19 +1,59 @@
 package bluto
 
+import (
+	"crypto/tls"
+
+	"github.com/alibaba-go/bluto/commander"
+)
+
 // Config is used to get initialization configs for Pool
 type Config struct {
 	// ---------------------------------------- dial options
-	Network               string
-	Address               string
-	Password              string
+	Network string
+	Address string
+	// Username is the ACL user (Redis>=6.0) which is authenticated with Password
+	Username string
+	Password string
+	// ClientName is set with CLIENT SETNAME on every new connection
+	ClientName string
+	// Database is selected on every new connection
+	Database              int
 	ConnectTimeoutSeconds int
 	ReadTimeoutSeconds    int
 	WriteTimeoutSeconds   int
 	KeepAliveSeconds      int
 
+	// ---------------------------------------- tls options
+	UseTLS bool
+	// TLSConfig is used for the TLS connections, if it is nil it is built from the options below
+	TLSConfig *tls.Config
+	// TLSCertFile and TLSKeyFile are the PEM files of the client certificate
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSCAFile is the PEM file of the certificate authorities which are used instead of the system ones
+	TLSCAFile     string
+	TLSServerName string
+	TLSSkipVerify bool
+
 	// ---------------------------------------- pool options
 	MaxIdle                int
 	MaxActive              int
 	IdleTimeoutSeconds     int
 	MaxConnLifetimeSeconds int
+
+	// ---------------------------------------- sentinel options
+	// SentinelAddresses are the sentinels which are asked for the address of the master instead of Address
+	SentinelAddresses  []string
+	SentinelMasterName string
+	// SentinelUsername is the ACL user of the sentinels which is authenticated with SentinelPassword
+	SentinelUsername string
+	SentinelPassword string
+
+	// ---------------------------------------- cluster options
+	// ClusterAddresses are the nodes which are asked for the slots of the cluster by NewCluster
+	ClusterAddresses []string
+
+	// ---------------------------------------- script options
+	// Scripts are loaded on every new connection of the pool
+	Scripts *commander.ScriptRegistry
 }
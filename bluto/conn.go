@@ -0,0 +1,76 @@
+package bluto
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba-go/bluto/commander"
+	"github.com/gomodule/redigo/redis"
+)
+
+// contextConn is a connection of the pools whose DoContext and ReceiveContext read the replies with the
+// read timeout which CommitContext passes with the context, the read is broken by closing the connection
+// as soon as the context is done
+type contextConn struct {
+	redis.Conn
+	readTimeout time.Duration
+}
+
+// DoWithTimeout is like Do with the read timeout of the reply, zero timeout means no read deadline.
+func (c *contextConn) DoWithTimeout(timeout time.Duration, commandName string, args ...interface{}) (interface{}, error) {
+	return redis.DoWithTimeout(c.Conn, timeout, commandName, args...)
+}
+
+// ReceiveWithTimeout is like Receive with the read timeout of the reply, zero timeout means no read deadline.
+func (c *contextConn) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	return redis.ReceiveWithTimeout(c.Conn, timeout)
+}
+
+// DoContext is like DoWithTimeout with the read timeout of the context, it returns ctx.Err() when the context is done.
+func (c *contextConn) DoContext(ctx context.Context, commandName string, args ...interface{}) (interface{}, error) {
+	return c.withContext(ctx, func(timeout time.Duration) (interface{}, error) {
+		return c.DoWithTimeout(timeout, commandName, args...)
+	})
+}
+
+// ReceiveContext is like ReceiveWithTimeout with the read timeout of the context, it returns ctx.Err() when the context is done.
+func (c *contextConn) ReceiveContext(ctx context.Context) (interface{}, error) {
+	return c.withContext(ctx, c.ReceiveWithTimeout)
+}
+
+// withContext reads in background with the read timeout of the context which is bounded by its deadline,
+// and closes the connection to break the read when the context is done
+func (c *contextConn) withContext(ctx context.Context, read func(timeout time.Duration) (interface{}, error)) (interface{}, error) {
+	timeout, ok := commander.ReadTimeout(ctx)
+	if !ok {
+		timeout = c.readTimeout
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			c.Conn.Close()
+			return nil, context.DeadlineExceeded
+		}
+		if timeout == 0 || remaining < timeout {
+			timeout = remaining
+		}
+	}
+	type result struct {
+		reply interface{}
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		reply, err := read(timeout)
+		results <- result{reply: reply, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		c.Conn.Close()
+		// the read fails as soon as the connection is closed, it is waited for so that the connection is not used after return
+		<-results
+		return nil, ctx.Err()
+	case r := <-results:
+		return r.reply, r.err
+	}
+}
@@ -0,0 +1,76 @@
+package bluto
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alibaba-go/bluto/commander"
+	"github.com/stretchr/testify/assert"
+)
+
+// listenSilent accepts the connections and reads their commands without ever replying
+func listenSilent(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(ioutil.Discard, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestCommitContextCanceledBlockingPop(t *testing.T) {
+	pool, err := GetPool(Config{Address: listenSilent(t)})
+	assert.Nil(t, err)
+	bl := &Bluto{pool: pool}
+	defer bl.ClosePool()
+	for i := 0; i < 2*pool.MaxActive; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		var blpopResult commander.ListElement
+		errCmd := bl.BorrowContext(ctx).
+			BLPop(&blpopResult, 0, "SomeList").
+			CommitContext(ctx)
+
+		assert.Equal(t, errCmd, context.Canceled)
+		assert.Eventually(t, func() bool {
+			return pool.ActiveCount() == 0
+		}, time.Second, 5*time.Millisecond)
+	}
+}
+
+func TestCommitContextBlockingPopTimeout(t *testing.T) {
+	pool, err := GetPool(Config{Address: listenSilent(t), ReadTimeoutSeconds: 1})
+	assert.Nil(t, err)
+	bl := &Bluto{pool: pool}
+	defer bl.ClosePool()
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	var blpopResult commander.ListElement
+	errCmd := bl.BorrowContext(ctx).
+		BLPop(&blpopResult, 0, "SomeList").
+		CommitContext(ctx)
+
+	// the blocking pop is only stopped by the deadline of the context, not by the read timeout of the connection
+	assert.NotNil(t, errCmd)
+	assert.True(t, time.Since(start) >= 1400*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return pool.ActiveCount() == 0
+	}, time.Second, 5*time.Millisecond)
+}
@@ -10,34 +10,10 @@ import (
 // GetPool returns a redis connection pool
 // which the users can use to borrows a connection from the pool
 func GetPool(config Config) (*redis.Pool, error) {
-	// TODO: use reflect to set the defaults
-	// set defaults
-	if config.Network == "" {
-		config.Network = "tcp"
-	}
-	if config.ConnectTimeoutSeconds == 0 {
-		config.ConnectTimeoutSeconds = 5
-	}
-	if config.ReadTimeoutSeconds == 0 {
-		config.ReadTimeoutSeconds = 5
-	}
-	if config.WriteTimeoutSeconds == 0 {
-		config.WriteTimeoutSeconds = 5
-	}
-	if config.KeepAliveSeconds == 0 {
-		config.KeepAliveSeconds = 300
-	}
-	if config.MaxIdle == 0 {
-		config.MaxIdle = 10
-	}
-	if config.MaxActive == 0 {
-		config.MaxActive = 10
-	}
-	if config.IdleTimeoutSeconds == 0 {
-		config.IdleTimeoutSeconds = 60
-	}
-	if config.MaxConnLifetimeSeconds == 0 {
-		config.MaxConnLifetimeSeconds = 120
+	config = withDefaults(config)
+	tlsConfig, err := getTLSConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
 	// time based dial options
@@ -54,15 +30,47 @@ func GetPool(config Config) (*redis.Pool, error) {
 	pool := &redis.Pool{
 		// Dial is used for creating and configuring a connection.
 		Dial: func() (redis.Conn, error) {
-			return redis.Dial(
+			// the password of an acl user is sent by setupConn
+			password := config.Password
+			if config.Username != "" {
+				password = ""
+			}
+			conn, err := redis.Dial(
 				config.Network,
 				config.Address,
-				redis.DialPassword(config.Password),
+				redis.DialPassword(password),
 				redis.DialConnectTimeout(connectTimeout),
 				redis.DialReadTimeout(readTimeout),
 				redis.DialWriteTimeout(writeTimeout),
 				redis.DialKeepAlive(keepAlive),
+				redis.DialUseTLS(config.UseTLS),
+				redis.DialTLSConfig(tlsConfig),
 			)
+			if err != nil {
+				return nil, err
+			}
+			err = setupConn(conn, config)
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+			// make sure the sentinel master is still a master
+			if config.SentinelMasterName != "" {
+				err = checkRole(conn)
+				if err != nil {
+					conn.Close()
+					return nil, err
+				}
+			}
+			// preload the registered scripts
+			if config.Scripts != nil {
+				err = config.Scripts.Load(conn)
+				if err != nil {
+					conn.Close()
+					return nil, err
+				}
+			}
+			return &contextConn{Conn: conn, readTimeout: readTimeout}, nil
 		},
 		// TestOnBorrow is optional and is used for checking
 		// the health of an idle connection before the connection is used again by
@@ -101,3 +109,60 @@ func GetPool(config Config) (*redis.Pool, error) {
 	}
 	return pool, nil
 }
+
+// setupConn authenticates the acl user, sets the client name and selects the database of a new connection
+func setupConn(conn redis.Conn, config Config) error {
+	if config.Username != "" {
+		_, err := conn.Do("AUTH", config.Username, config.Password)
+		if err != nil {
+			return err
+		}
+	}
+	if config.ClientName != "" {
+		_, err := conn.Do("CLIENT", "SETNAME", config.ClientName)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Database != 0 {
+		_, err := conn.Do("SELECT", config.Database)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withDefaults returns the config with the defaults of the unset options
+func withDefaults(config Config) Config {
+	// TODO: use reflect to set the defaults
+	// set defaults
+	if config.Network == "" {
+		config.Network = "tcp"
+	}
+	if config.ConnectTimeoutSeconds == 0 {
+		config.ConnectTimeoutSeconds = 5
+	}
+	if config.ReadTimeoutSeconds == 0 {
+		config.ReadTimeoutSeconds = 5
+	}
+	if config.WriteTimeoutSeconds == 0 {
+		config.WriteTimeoutSeconds = 5
+	}
+	if config.KeepAliveSeconds == 0 {
+		config.KeepAliveSeconds = 300
+	}
+	if config.MaxIdle == 0 {
+		config.MaxIdle = 10
+	}
+	if config.MaxActive == 0 {
+		config.MaxActive = 10
+	}
+	if config.IdleTimeoutSeconds == 0 {
+		config.IdleTimeoutSeconds = 60
+	}
+	if config.MaxConnLifetimeSeconds == 0 {
+		config.MaxConnLifetimeSeconds = 120
+	}
+	return config
+}
@@ -1,12 +1,24 @@
 package bluto_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/alibaba-go/bluto/bluto"
+	"github.com/alibaba-go/bluto/commander"
+	"github.com/gomodule/redigo/redis"
 )
 
 var _ = Describe("Pooler", func() {
@@ -53,5 +65,192 @@ var _ = Describe("Pooler", func() {
 			Expect(pool).To(Not(BeNil()))
 			Expect(errClose).To(BeNil())
 		})
+
+		It("should load the registered scripts on new connections", func() {
+			script := commander.NewScript("return 'loaded'")
+			config := getCorrectConfig()
+			config.Scripts = commander.NewScriptRegistry(script)
+			pool, err := bluto.GetPool(config)
+			conn := pool.Get()
+			result, errDo := redis.String(conn.Do("EVALSHA", script.Hash(), 0))
+			errConn := conn.Close()
+			errClose := pool.Close()
+
+			Expect(err).To(BeNil())
+			Expect(errDo).To(BeNil())
+			Expect(result).To(Equal("loaded"))
+			Expect(errConn).To(BeNil())
+			Expect(errClose).To(BeNil())
+		})
+	})
+
+	Describe("GetPool with connection options", func() {
+		It("should set the client name and select the database of new connections", func() {
+			config := getCorrectConfig()
+			config.ClientName = "SomeClient"
+			config.Database = 1
+			pool, err := bluto.GetPool(config)
+			conn := pool.Get()
+			name, errName := redis.String(conn.Do("CLIENT", "GETNAME"))
+			_, errSet := conn.Do("SET", "SomeDatabaseKey", "SomeValue")
+			_, errSelect := conn.Do("SELECT", 0)
+			exists, errExists := redis.Int(conn.Do("EXISTS", "SomeDatabaseKey"))
+			_, errSelect1 := conn.Do("SELECT", 1)
+			_, errDel := conn.Do("DEL", "SomeDatabaseKey")
+			errConn := conn.Close()
+			errClose := pool.Close()
+
+			Expect(err).To(BeNil())
+			Expect(errName).To(BeNil())
+			Expect(name).To(Equal("SomeClient"))
+			Expect(errSet).To(BeNil())
+			Expect(errSelect).To(BeNil())
+			Expect(errExists).To(BeNil())
+			Expect(exists).To(Equal(0))
+			Expect(errSelect1).To(BeNil())
+			Expect(errDel).To(BeNil())
+			Expect(errConn).To(BeNil())
+			Expect(errClose).To(BeNil())
+		})
+
+		It("should not connect with an invalid acl user", func() {
+			config := getCorrectConfig()
+			config.Username = "NotExistUser"
+			config.Password = "SomePassword"
+			pool, err := bluto.GetPool(config)
+			conn := pool.Get()
+			_, errDo := conn.Do("PING")
+			errClose := pool.Close()
+
+			Expect(err).To(BeNil())
+			Expect(errDo).To(Not(BeNil()))
+			Expect(errClose).To(BeNil())
+		})
+	})
+
+	Describe("GetPool with tls options", func() {
+		// writeCertificate writes a self signed certificate and its key to temp files
+		var writeCertificate = func() (string, string) {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).To(BeNil())
+			template := &x509.Certificate{
+				SerialNumber:          big.NewInt(1),
+				Subject:               pkix.Name{CommonName: "localhost"},
+				NotBefore:             time.Now(),
+				NotAfter:              time.Now().Add(time.Hour),
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).To(BeNil())
+			keyDer, err := x509.MarshalECPrivateKey(key)
+			Expect(err).To(BeNil())
+			certFile, err := ioutil.TempFile("", "cert*.pem")
+			Expect(err).To(BeNil())
+			defer certFile.Close()
+			keyFile, err := ioutil.TempFile("", "key*.pem")
+			Expect(err).To(BeNil())
+			defer keyFile.Close()
+			Expect(pem.Encode(certFile, &pem.Block{Type: "CERTIFICATE", Bytes: der})).To(BeNil())
+			Expect(pem.Encode(keyFile, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})).To(BeNil())
+			return certFile.Name(), keyFile.Name()
+		}
+
+		It("should create a pool with valid tls options", func() {
+			certFile, keyFile := writeCertificate()
+			defer os.Remove(certFile)
+			defer os.Remove(keyFile)
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSCertFile = certFile
+			config.TLSKeyFile = keyFile
+			config.TLSCAFile = certFile
+			config.TLSServerName = "localhost"
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(BeNil())
+			Expect(pool).To(Not(BeNil()))
+			Expect(pool.Close()).To(BeNil())
+		})
+
+		It("should create a pool with tls config", func() {
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSConfig = &tls.Config{ServerName: "localhost"}
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(BeNil())
+			Expect(pool).To(Not(BeNil()))
+			Expect(pool.Close()).To(BeNil())
+		})
+
+		It("should not create a pool with tls options without UseTLS", func() {
+			config := getCorrectConfig()
+			config.TLSSkipVerify = true
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(pool).To(BeNil())
+		})
+
+		It("should not create a pool with tls options and tls config", func() {
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSConfig = &tls.Config{}
+			config.TLSServerName = "localhost"
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(pool).To(BeNil())
+		})
+
+		It("should not create a pool with a client certificate without key", func() {
+			certFile, keyFile := writeCertificate()
+			defer os.Remove(certFile)
+			defer os.Remove(keyFile)
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSCertFile = certFile
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(pool).To(BeNil())
+		})
+
+		It("should create a pool with skip verify and server name", func() {
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSSkipVerify = true
+			config.TLSServerName = "localhost"
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(BeNil())
+			Expect(pool).To(Not(BeNil()))
+			Expect(pool.Close()).To(BeNil())
+		})
+
+		It("should not create a pool with skip verify and ca file", func() {
+			certFile, keyFile := writeCertificate()
+			defer os.Remove(certFile)
+			defer os.Remove(keyFile)
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSCAFile = certFile
+			config.TLSSkipVerify = true
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(pool).To(BeNil())
+		})
+
+		It("should not create a pool with an invalid ca file", func() {
+			config := getCorrectConfig()
+			config.UseTLS = true
+			config.TLSCAFile = "NotExistFile.pem"
+			pool, err := bluto.GetPool(config)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(pool).To(BeNil())
+		})
 	})
 })
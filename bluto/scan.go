@@ -0,0 +1,146 @@
+package bluto
+
+import (
+	"context"
+
+	"github.com/alibaba-go/bluto/commander"
+	"github.com/gomodule/redigo/redis"
+)
+
+// ScanOptions are the options of the SCAN family of commands
+type ScanOptions struct {
+	// Match only returns the elements which match the glob-style pattern
+	Match string
+	// Count is the hint of the number of elements which are returned in each batch
+	Count int
+	// Type only returns the keys of the type, it is only used by Scan (Redis>=6.0)
+	Type string
+}
+
+// ScanIterator iterates over the batches of a cursor-based SCAN, HSCAN, SSCAN or ZSCAN on its own borrowed connection.
+// The connection is returned to the pool when the iteration ends or the iterator is closed.
+type ScanIterator struct {
+	ctx       context.Context
+	commander *commander.Commander
+	command   string
+	key       string
+	options   ScanOptions
+	cursor    string
+	batch     []string
+	done      bool
+	err       error
+}
+
+// Scan returns an iterator over the keys of the current database
+func (bl *Bluto) Scan(ctx context.Context, options ScanOptions) *ScanIterator {
+	return bl.scan(ctx, "SCAN", "", options)
+}
+
+// HScan returns an iterator over the fields and values of the hash stored at key, each batch holds the field value pairs in order
+func (bl *Bluto) HScan(ctx context.Context, key string, options ScanOptions) *ScanIterator {
+	return bl.scan(ctx, "HSCAN", key, options)
+}
+
+// SScan returns an iterator over the members of the set stored at key
+func (bl *Bluto) SScan(ctx context.Context, key string, options ScanOptions) *ScanIterator {
+	return bl.scan(ctx, "SSCAN", key, options)
+}
+
+// ZScan returns an iterator over the members and scores of the sorted set stored at key, each batch holds the member score pairs in order
+func (bl *Bluto) ZScan(ctx context.Context, key string, options ScanOptions) *ScanIterator {
+	return bl.scan(ctx, "ZSCAN", key, options)
+}
+
+// scan borrows the connection of a new iterator
+func (bl *Bluto) scan(ctx context.Context, command, key string, options ScanOptions) *ScanIterator {
+	it := &ScanIterator{
+		ctx:     ctx,
+		command: command,
+		key:     key,
+		options: options,
+		cursor:  "0",
+	}
+	conn, err := bl.getPool().GetContext(ctx)
+	if err != nil {
+		it.done = true
+		it.err = err
+		return it
+	}
+	it.commander = commander.New(conn)
+	return it
+}
+
+// Next fetches the next batch and returns false when the iteration is over, because all the
+// elements are returned, the context is done or an error has occurred which is returned by Err.
+func (it *ScanIterator) Next() bool {
+	it.batch = nil
+	for !it.done {
+		if err := it.ctx.Err(); err != nil {
+			it.stop(err)
+			return false
+		}
+		var reply []interface{}
+		err := it.commander.Command(&reply, it.command, it.args()...).Exec()
+		if err == nil {
+			_, err = redis.Scan(reply, &it.cursor, &it.batch)
+		}
+		if err != nil {
+			it.stop(err)
+			return false
+		}
+		// the iteration is over when the server returns the cursor 0
+		if it.cursor == "0" {
+			it.stop(nil)
+		}
+		// a batch may be empty while the iteration is not over
+		if len(it.batch) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Batch returns the current batch of keys, members, field value pairs or member score pairs.
+func (it *ScanIterator) Batch() []string {
+	return it.batch
+}
+
+// Err returns the error which has stopped the iteration.
+func (it *ScanIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and returns the connection to the pool.
+func (it *ScanIterator) Close() error {
+	it.stop(nil)
+	return nil
+}
+
+// args returns the arguments of the next command of the cursor loop
+func (it *ScanIterator) args() []interface{} {
+	args := redis.Args{}
+	if it.command != "SCAN" {
+		args = args.Add(it.key)
+	}
+	args = args.Add(it.cursor)
+	if it.options.Match != "" {
+		args = args.Add("MATCH", it.options.Match)
+	}
+	if it.options.Count > 0 {
+		args = args.Add("COUNT", it.options.Count)
+	}
+	if it.options.Type != "" && it.command == "SCAN" {
+		args = args.Add("TYPE", it.options.Type)
+	}
+	return args
+}
+
+// stop ends the iteration with the error and releases the connection
+func (it *ScanIterator) stop(err error) {
+	if it.done {
+		return
+	}
+	it.done = true
+	it.err = err
+	it.commander.Release()
+}
@@ -0,0 +1,85 @@
+package bluto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rafaeljusto/redigomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockBluto returns a bluto whose pool dials the mock connection
+func newMockBluto(conn *redigomock.Conn) *Bluto {
+	return &Bluto{pool: &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return conn, nil
+		},
+	}}
+}
+
+func TestScan(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("SCAN", "0", "MATCH", "Some*", "COUNT", 2, "TYPE", "string").
+		Expect([]interface{}{[]byte("17"), []interface{}{[]byte("SomeKey1"), []byte("SomeKey2")}})
+	conn.Command("SCAN", "17", "MATCH", "Some*", "COUNT", 2, "TYPE", "string").
+		Expect([]interface{}{[]byte("9"), []interface{}{}})
+	conn.Command("SCAN", "9", "MATCH", "Some*", "COUNT", 2, "TYPE", "string").
+		Expect([]interface{}{[]byte("0"), []interface{}{[]byte("SomeKey3")}})
+	bl := newMockBluto(conn)
+	it := bl.Scan(context.Background(), ScanOptions{Match: "Some*", Count: 2, Type: "string"})
+	var batches [][]string
+	for it.Next() {
+		batches = append(batches, it.Batch())
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, batches, [][]string{{"SomeKey1", "SomeKey2"}, {"SomeKey3"}})
+	assert.Equal(t, bl.pool.ActiveCount(), 0)
+}
+
+func TestHScanCanceled(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("HSCAN", "SomeKey", "0").
+		Expect([]interface{}{[]byte("3"), []interface{}{[]byte("field"), []byte("value")}})
+	bl := newMockBluto(conn)
+	ctx, cancel := context.WithCancel(context.Background())
+	it := bl.HScan(ctx, "SomeKey", ScanOptions{})
+	assert.True(t, it.Next())
+	assert.Equal(t, it.Batch(), []string{"field", "value"})
+	cancel()
+	assert.False(t, it.Next())
+	assert.Equal(t, it.Err(), context.Canceled)
+	assert.Equal(t, bl.pool.ActiveCount(), 0)
+}
+
+func TestScanError(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("SSCAN", "SomeKey", "0").Expect(redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	bl := newMockBluto(conn)
+	it := bl.SScan(context.Background(), "SomeKey", ScanOptions{})
+	assert.False(t, it.Next())
+	assert.NotNil(t, it.Err())
+	assert.Nil(t, it.Close())
+}
+
+func TestScanOwnConnection(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("SCAN", "0").
+		Expect([]interface{}{[]byte("17"), []interface{}{[]byte("SomeKey1")}})
+	conn.Command("SCAN", "17").
+		Expect([]interface{}{[]byte("9"), []interface{}{[]byte("SomeKey2")}})
+	dials := 0
+	bl := &Bluto{pool: &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			dials++
+			return conn, nil
+		},
+	}}
+	it := bl.Scan(context.Background(), ScanOptions{})
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	assert.Equal(t, bl.pool.ActiveCount(), 1)
+	assert.Nil(t, it.Close())
+	assert.Equal(t, bl.pool.ActiveCount(), 0)
+	assert.Equal(t, dials, 1)
+}
@@ -0,0 +1,193 @@
+package bluto
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// sentinelSwitchChannel is the sentinel channel which announces the new master after a failover
+	sentinelSwitchChannel = "+switch-master"
+	// sentinelCheckPeriod is the period of asking the sentinels for the master in case of a missed announcement
+	sentinelCheckPeriod = 30 * time.Second
+)
+
+// newSentinel creates new Bluto instance for the master of the sentinels which follows the failovers
+func newSentinel(config Config) (*Bluto, error) {
+	address, err := sentinelMaster(config)
+	if err != nil {
+		return nil, err
+	}
+	config.Address = address
+	pool, err := GetPool(config)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bl := &Bluto{
+		pool:   pool,
+		config: config,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	subscription, err := newSubscription(ctx, func() (redis.Conn, error) {
+		return dialSentinels(config)
+	}, []string{sentinelSwitchChannel}, nil)
+	if err != nil {
+		cancel()
+		pool.Close()
+		return nil, err
+	}
+	go bl.watchSentinel(ctx, subscription)
+	return bl, nil
+}
+
+// watchSentinel switches the pool to the new master when the sentinels announce a failover
+func (bl *Bluto) watchSentinel(ctx context.Context, subscription *Subscription) {
+	defer close(bl.done)
+	defer subscription.Close()
+	ticker := time.NewTicker(sentinelCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-subscription.Messages():
+			if !ok {
+				return
+			}
+			name, address, ok := parseSwitchMaster(string(message.Data))
+			if ok && name == bl.config.SentinelMasterName {
+				bl.switchMaster(address)
+			}
+		case <-ticker.C:
+			address, err := sentinelMaster(bl.config)
+			if err == nil {
+				bl.switchMaster(address)
+			}
+		}
+	}
+}
+
+// switchMaster replaces the pool with a pool of the new master, the connections of the old pool
+// are drained: the idle ones are closed now and the borrowed ones when they are returned
+func (bl *Bluto) switchMaster(address string) error {
+	bl.mu.Lock()
+	if bl.config.Address == address {
+		bl.mu.Unlock()
+		return nil
+	}
+	config := bl.config
+	config.Address = address
+	pool, err := GetPool(config)
+	if err != nil {
+		bl.mu.Unlock()
+		return err
+	}
+	oldPool := bl.pool
+	bl.pool = pool
+	bl.config = config
+	bl.mu.Unlock()
+	return oldPool.Close()
+}
+
+// sentinelMaster returns the address of the master from the first sentinel which knows it
+func sentinelMaster(config Config) (string, error) {
+	err := errors.New("bluto: no sentinel address")
+	for _, sentinelAddress := range config.SentinelAddresses {
+		var conn redis.Conn
+		conn, err = dialSentinel(config, sentinelAddress)
+		if err != nil {
+			continue
+		}
+		var master []string
+		master, err = redis.Strings(conn.Do("SENTINEL", "get-master-addr-by-name", config.SentinelMasterName))
+		conn.Close()
+		if err == redis.ErrNil || (err == nil && len(master) != 2) {
+			err = errors.New("bluto: unknown sentinel master " + config.SentinelMasterName)
+		}
+		if err == nil {
+			return net.JoinHostPort(master[0], master[1]), nil
+		}
+	}
+	return "", err
+}
+
+// dialSentinels dials the first available sentinel
+func dialSentinels(config Config) (redis.Conn, error) {
+	err := errors.New("bluto: no sentinel address")
+	for _, sentinelAddress := range config.SentinelAddresses {
+		var conn redis.Conn
+		conn, err = dialSentinel(config, sentinelAddress)
+		if err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// dialSentinel dials the sentinel at the address with the dial and tls options of the config
+func dialSentinel(config Config, address string) (redis.Conn, error) {
+	config = withDefaults(config)
+	tlsConfig, err := getTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	// the password of an acl user is sent with AUTH after the dial
+	password := config.SentinelPassword
+	if config.SentinelUsername != "" {
+		password = ""
+	}
+	conn, err := redis.Dial(
+		config.Network,
+		address,
+		redis.DialPassword(password),
+		redis.DialConnectTimeout(time.Duration(config.ConnectTimeoutSeconds)*time.Second),
+		redis.DialReadTimeout(time.Duration(config.ReadTimeoutSeconds)*time.Second),
+		redis.DialWriteTimeout(time.Duration(config.WriteTimeoutSeconds)*time.Second),
+		redis.DialUseTLS(config.UseTLS),
+		redis.DialTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if config.SentinelUsername != "" {
+		_, err = conn.Do("AUTH", config.SentinelUsername, config.SentinelPassword)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// checkRole makes sure the connection is to a master with ROLE
+func checkRole(conn redis.Conn) error {
+	role, err := redis.Values(conn.Do("ROLE"))
+	if err != nil {
+		return err
+	}
+	var name string
+	_, err = redis.Scan(role, &name)
+	if err != nil {
+		return err
+	}
+	if name != "master" {
+		return errors.New("bluto: the sentinel master has the role " + name)
+	}
+	return nil
+}
+
+// parseSwitchMaster parses the +switch-master message e.g. mymaster 127.0.0.1 6379 127.0.0.1 6380
+func parseSwitchMaster(message string) (name, address string, ok bool) {
+	parts := strings.Fields(message)
+	if len(parts) != 5 {
+		return "", "", false
+	}
+	return parts[0], net.JoinHostPort(parts[3], parts[4]), true
+}
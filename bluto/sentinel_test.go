@@ -0,0 +1,106 @@
+package bluto
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/redigomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSwitchMaster(t *testing.T) {
+	name, address, ok := parseSwitchMaster("mymaster 127.0.0.1 6379 127.0.0.1 6380")
+	assert.True(t, ok)
+	assert.Equal(t, name, "mymaster")
+	assert.Equal(t, address, "127.0.0.1:6380")
+
+	_, _, ok = parseSwitchMaster("mymaster 127.0.0.1 6379")
+	assert.False(t, ok)
+}
+
+func TestCheckRole(t *testing.T) {
+	master := redigomock.NewConn()
+	master.Command("ROLE").ExpectSlice([]byte("master"), int64(0), []interface{}{})
+	assert.Nil(t, checkRole(master))
+
+	replica := redigomock.NewConn()
+	replica.Command("ROLE").ExpectSlice([]byte("slave"), []byte("127.0.0.1"), int64(6379), []byte("connected"), int64(0))
+	assert.NotNil(t, checkRole(replica))
+}
+
+func TestSwitchMaster(t *testing.T) {
+	config := Config{Address: "127.0.0.1:6379", SentinelMasterName: "mymaster"}
+	pool, err := GetPool(config)
+	assert.Nil(t, err)
+	bl := &Bluto{pool: pool, config: config}
+
+	err = bl.switchMaster("127.0.0.1:6380")
+	assert.Nil(t, err)
+	assert.Equal(t, bl.config.Address, "127.0.0.1:6380")
+	assert.NotEqual(t, bl.getPool(), pool)
+	// the old pool is drained
+	assert.NotNil(t, pool.Get().Err())
+
+	newPool := bl.getPool()
+	err = bl.switchMaster("127.0.0.1:6380")
+	assert.Nil(t, err)
+	assert.Equal(t, bl.getPool(), newPool)
+	assert.Nil(t, bl.ClosePool())
+}
+
+// listenSentinel accepts a connection and sends the first command to the channel after replying +OK to it,
+// or only the first byte if it is the handshake record of a tls connection
+func listenSentinel(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	commands := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		first, err := reader.Peek(1)
+		if err != nil || first[0] == 0x16 {
+			commands <- string(first)
+			return
+		}
+		// the array header is followed by a length and a value line for each argument
+		header, _ := reader.ReadString('\n')
+		command := header
+		arguments, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+		for i := 0; i < 2*arguments; i++ {
+			line, _ := reader.ReadString('\n')
+			command += line
+		}
+		conn.Write([]byte("+OK\r\n"))
+		commands <- command
+	}()
+	return listener.Addr().String(), commands
+}
+
+func TestDialSentinelUsername(t *testing.T) {
+	address, lines := listenSentinel(t)
+	conn, err := dialSentinel(Config{SentinelUsername: "SomeUser", SentinelPassword: "SomePassword"}, address)
+	assert.Nil(t, err)
+	conn.Close()
+	assert.Equal(t, <-lines, "*3\r\n$4\r\nAUTH\r\n$8\r\nSomeUser\r\n$12\r\nSomePassword\r\n")
+}
+
+func TestDialSentinelTLS(t *testing.T) {
+	address, lines := listenSentinel(t)
+	_, err := dialSentinel(Config{UseTLS: true, TLSSkipVerify: true, ConnectTimeoutSeconds: 1}, address)
+	assert.NotNil(t, err)
+	// a tls connection starts with the handshake record
+	assert.Equal(t, (<-lines)[0], byte(0x16))
+}
+
+func TestDialSentinelInvalidTLS(t *testing.T) {
+	_, err := dialSentinel(Config{TLSSkipVerify: true}, "127.0.0.1:26379")
+	assert.NotNil(t, err)
+}
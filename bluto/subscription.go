@@ -0,0 +1,266 @@
+package bluto
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// subscriptionPingPeriod is the period of pings which check the health of the subscription connection,
+// it is a variable so that the tests can shorten it
+var subscriptionPingPeriod = 10 * time.Second
+
+const (
+	// subscriptionMinBackoff is the wait duration before the first reconnect of a subscription
+	subscriptionMinBackoff = 100 * time.Millisecond
+	// subscriptionMaxBackoff is the maximum wait duration between the reconnects of a subscription
+	subscriptionMaxBackoff = 5 * time.Second
+)
+
+// Message is a message which is received by a subscription
+type Message struct {
+	// Channel is the channel which the message is published to
+	Channel string
+	// Pattern is the matched pattern of PSubscribe, it is empty for channel subscriptions
+	Pattern string
+	// Data is the published message
+	Data []byte
+}
+
+// Subscription is a pub/sub subscription over a dedicated connection out of the pool.
+// It pings the connection to detect dead connections and reconnects and subscribes
+// again to all of its channels and patterns when the connection is lost.
+type Subscription struct {
+	dial     func() (redis.Conn, error)
+	messages chan Message
+	cancel   context.CancelFunc
+	done     chan struct{}
+	// pingPeriod is the period of the pings, the subscription connection is dead without any reply in two periods
+	pingPeriod time.Duration
+
+	// mu guards the fields below and the writes to the connection
+	mu       sync.Mutex
+	conn     redis.Conn
+	count    int
+	channels map[string]struct{}
+	patterns map[string]struct{}
+}
+
+// newSubscription connects a new subscription which lasts until the context is done or it is closed
+func newSubscription(ctx context.Context, dial func() (redis.Conn, error), channels, patterns []string) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		dial:       dial,
+		messages:   make(chan Message),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+		pingPeriod: subscriptionPingPeriod,
+		channels:   make(map[string]struct{}),
+		patterns:   make(map[string]struct{}),
+	}
+	for _, channel := range channels {
+		s.channels[channel] = struct{}{}
+	}
+	for _, pattern := range patterns {
+		s.patterns[pattern] = struct{}{}
+	}
+	conn, err := s.connect()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	go s.run(ctx, conn)
+	return s, nil
+}
+
+// Messages returns the channel which delivers the received messages, it is closed when the subscription is closed.
+func (s *Subscription) Messages() <-chan Message {
+	return s.messages
+}
+
+// Subscribe subscribes to the given channels.
+func (s *Subscription) Subscribe(channels ...string) error {
+	return s.update(s.channels, true, "SUBSCRIBE", channels)
+}
+
+// PSubscribe subscribes to the given patterns.
+func (s *Subscription) PSubscribe(patterns ...string) error {
+	return s.update(s.patterns, true, "PSUBSCRIBE", patterns)
+}
+
+// Unsubscribe unsubscribes from the given channels, or from all of them if none is given.
+func (s *Subscription) Unsubscribe(channels ...string) error {
+	return s.update(s.channels, false, "UNSUBSCRIBE", channels)
+}
+
+// PUnsubscribe unsubscribes from the given patterns, or from all of them if none is given.
+func (s *Subscription) PUnsubscribe(patterns ...string) error {
+	return s.update(s.patterns, false, "PUNSUBSCRIBE", patterns)
+}
+
+// Close closes the subscription and its connection.
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// update adds or removes the names from the set and sends the command to the current connection,
+// the set is used to subscribe again after a reconnect
+func (s *Subscription) update(set map[string]struct{}, add bool, command string, names []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(names) == 0 {
+		if add {
+			return nil
+		}
+		// unsubscribe from all of them
+		for name := range set {
+			delete(set, name)
+		}
+	}
+	for _, name := range names {
+		if add {
+			set[name] = struct{}{}
+		} else {
+			delete(set, name)
+		}
+	}
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Send(command, redis.Args{}.AddFlat(names)...)
+	if err == nil {
+		err = s.conn.Flush()
+	}
+	if err != nil {
+		// the receive loop reconnects and subscribes again
+		s.conn.Close()
+	}
+	return err
+}
+
+// connect dials a new connection and subscribes to all the channels and patterns
+func (s *Subscription) connect() (redis.Conn, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.channels) > 0 {
+		err = conn.Send("SUBSCRIBE", keys(s.channels)...)
+	}
+	if err == nil && len(s.patterns) > 0 {
+		err = conn.Send("PSUBSCRIBE", keys(s.patterns)...)
+	}
+	if err == nil {
+		err = conn.Flush()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.conn = conn
+	s.count = 0
+	return conn, nil
+}
+
+// run receives the messages and reconnects with a backoff until the context is done
+func (s *Subscription) run(ctx context.Context, conn redis.Conn) {
+	defer close(s.done)
+	defer close(s.messages)
+	backoff := subscriptionMinBackoff
+	for {
+		if conn != nil {
+			backoff = subscriptionMinBackoff
+			s.receive(ctx, conn)
+		}
+		// wait before the reconnect
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > subscriptionMaxBackoff {
+			backoff = subscriptionMaxBackoff
+		}
+		conn, _ = s.connect()
+	}
+}
+
+// receive delivers the messages of the connection until the connection is lost or the context is done
+func (s *Subscription) receive(ctx context.Context, conn redis.Conn) {
+	stop := make(chan struct{})
+	defer func() {
+		close(stop)
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+		conn.Close()
+	}()
+	go s.keepAlive(ctx, conn, stop)
+	psc := redis.PubSubConn{Conn: conn}
+	for {
+		// without any subscription there is no ping to wait for
+		s.mu.Lock()
+		timeout := time.Duration(0)
+		if s.count > 0 {
+			timeout = 2 * s.pingPeriod
+		}
+		s.mu.Unlock()
+		switch reply := psc.ReceiveWithTimeout(timeout).(type) {
+		case redis.Message:
+			select {
+			case s.messages <- Message{Channel: reply.Channel, Pattern: reply.Pattern, Data: reply.Data}:
+			case <-ctx.Done():
+				return
+			}
+		case redis.Subscription:
+			s.mu.Lock()
+			s.count = reply.Count
+			s.mu.Unlock()
+		case error:
+			return
+		}
+	}
+}
+
+// keepAlive pings the connection periodically and closes it when the context is done
+func (s *Subscription) keepAlive(ctx context.Context, conn redis.Conn, stop chan struct{}) {
+	ticker := time.NewTicker(s.pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			// stop the blocked receive
+			conn.Close()
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			var err error
+			if s.count > 0 {
+				err = redis.PubSubConn{Conn: conn}.Ping("")
+			}
+			s.mu.Unlock()
+			if err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// keys returns the members of the set as command arguments
+func keys(set map[string]struct{}) []interface{} {
+	args := make([]interface{}, 0, len(set))
+	for key := range set {
+		args = append(args, key)
+	}
+	return args
+}
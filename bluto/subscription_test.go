@@ -0,0 +1,174 @@
+package bluto
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSubConn is a subscription connection which confirms the subscriptions and records the sent commands,
+// it replies to the pings unless it is dead
+type fakeSubConn struct {
+	mu       sync.Mutex
+	commands map[string][]string
+	count    int
+	dead     bool
+
+	replies   chan interface{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakeSubConn(dead bool) *fakeSubConn {
+	return &fakeSubConn{
+		commands: make(map[string][]string),
+		dead:     dead,
+		replies:  make(chan interface{}, 100),
+		closed:   make(chan struct{}),
+	}
+}
+
+// sent returns the arguments of the sent commands with the name
+func (fc *fakeSubConn) sent(name string) []string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return append([]string(nil), fc.commands[name]...)
+}
+
+func (fc *fakeSubConn) Send(name string, args ...interface{}) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if name == "PING" {
+		fc.commands[name] = append(fc.commands[name], "")
+		if !fc.dead {
+			fc.replies <- []interface{}{[]byte("pong"), []byte("")}
+		}
+		return nil
+	}
+	kind := map[string]string{"SUBSCRIBE": "subscribe", "PSUBSCRIBE": "psubscribe"}[name]
+	for _, arg := range args {
+		fc.commands[name] = append(fc.commands[name], arg.(string))
+		if kind != "" {
+			fc.count++
+			fc.replies <- []interface{}{[]byte(kind), []byte(arg.(string)), int64(fc.count)}
+		}
+	}
+	return nil
+}
+
+func (fc *fakeSubConn) Flush() error {
+	return nil
+}
+
+func (fc *fakeSubConn) Do(name string, args ...interface{}) (interface{}, error) {
+	return nil, fc.Send(name, args...)
+}
+
+func (fc *fakeSubConn) DoWithTimeout(timeout time.Duration, name string, args ...interface{}) (interface{}, error) {
+	return fc.Do(name, args...)
+}
+
+func (fc *fakeSubConn) Receive() (interface{}, error) {
+	return fc.ReceiveWithTimeout(0)
+}
+
+func (fc *fakeSubConn) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		expired = time.After(timeout)
+	}
+	select {
+	case reply := <-fc.replies:
+		return reply, nil
+	case <-fc.closed:
+		return nil, errors.New("use of closed connection")
+	case <-expired:
+		return nil, errors.New("i/o timeout")
+	}
+}
+
+// isClosed reports whether the connection is closed
+func (fc *fakeSubConn) isClosed() bool {
+	select {
+	case <-fc.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (fc *fakeSubConn) Close() error {
+	fc.closeOnce.Do(func() {
+		close(fc.closed)
+	})
+	return nil
+}
+
+func (fc *fakeSubConn) Err() error {
+	return nil
+}
+
+// dialFakeSubConns returns a dial function which returns the connections in order
+func dialFakeSubConns(conns ...*fakeSubConn) func() (redis.Conn, error) {
+	var mu sync.Mutex
+	return func() (redis.Conn, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(conns) == 0 {
+			return nil, errors.New("no more connections")
+		}
+		conn := conns[0]
+		conns = conns[1:]
+		return conn, nil
+	}
+}
+
+func TestSubscriptionReconnect(t *testing.T) {
+	first := newFakeSubConn(false)
+	second := newFakeSubConn(false)
+	subscription, err := newSubscription(context.Background(), dialFakeSubConns(first, second), []string{"channel1"}, []string{"pattern1*"})
+	assert.Nil(t, err)
+	defer subscription.Close()
+	assert.Nil(t, subscription.Subscribe("channel2"))
+	assert.Nil(t, subscription.PSubscribe("pattern2*"))
+	assert.ElementsMatch(t, first.sent("SUBSCRIBE"), []string{"channel1", "channel2"})
+	assert.ElementsMatch(t, first.sent("PSUBSCRIBE"), []string{"pattern1*", "pattern2*"})
+
+	// drop the first connection
+	first.Close()
+
+	assert.Eventually(t, func() bool {
+		return len(second.sent("SUBSCRIBE")) == 2 && len(second.sent("PSUBSCRIBE")) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, second.sent("SUBSCRIBE"), []string{"channel1", "channel2"})
+	assert.ElementsMatch(t, second.sent("PSUBSCRIBE"), []string{"pattern1*", "pattern2*"})
+}
+
+func TestSubscriptionDeadConnection(t *testing.T) {
+	pingPeriod := subscriptionPingPeriod
+	subscriptionPingPeriod = 20 * time.Millisecond
+	defer func() {
+		subscriptionPingPeriod = pingPeriod
+	}()
+	// the first connection is dead, its pings are sent but never answered
+	first := newFakeSubConn(true)
+	second := newFakeSubConn(false)
+	subscription, err := newSubscription(context.Background(), dialFakeSubConns(first, second), []string{"channel1"}, nil)
+	assert.Nil(t, err)
+	defer subscription.Close()
+
+	assert.Eventually(t, func() bool {
+		return len(second.sent("SUBSCRIBE")) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, first.isClosed())
+	assert.NotEmpty(t, first.sent("PING"))
+	// the healthy connection answers the pings and is kept
+	time.Sleep(10 * subscriptionPingPeriod)
+	assert.False(t, second.isClosed())
+	assert.NotEmpty(t, second.sent("PING"))
+}
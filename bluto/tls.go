@@ -0,0 +1,59 @@
+package bluto
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+)
+
+// getTLSConfig validates the tls options and returns the tls config of the connections
+func getTLSConfig(config Config) (*tls.Config, error) {
+	hasOptions := config.TLSCertFile != "" || config.TLSKeyFile != "" || config.TLSCAFile != "" ||
+		config.TLSServerName != "" || config.TLSSkipVerify
+	if !config.UseTLS {
+		if hasOptions || config.TLSConfig != nil {
+			return nil, errors.New("bluto: tls options are set without UseTLS")
+		}
+		return nil, nil
+	}
+	if config.TLSConfig != nil {
+		if hasOptions {
+			return nil, errors.New("bluto: tls options can not be used with TLSConfig")
+		}
+		return config.TLSConfig, nil
+	}
+	if (config.TLSCertFile == "") != (config.TLSKeyFile == "") {
+		return nil, errors.New("bluto: both TLSCertFile and TLSKeyFile are required for a client certificate")
+	}
+	// the server name is still sent for SNI when the verification is skipped
+	if config.TLSSkipVerify && config.TLSCAFile != "" {
+		return nil, errors.New("bluto: TLSSkipVerify can not be used with TLSCAFile")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         config.TLSServerName,
+		InsecureSkipVerify: config.TLSSkipVerify,
+	}
+	// client certificate
+	if config.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	// custom certificate authorities
+	if config.TLSCAFile != "" {
+		ca, err := ioutil.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("bluto: no certificate is found in TLSCAFile")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,90 @@
+package commander
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// BitFieldType is the type of an integer field of BITFIELD like u8 or i16.
+type BitFieldType string
+
+// BitFieldUnsigned returns the type of an unsigned integer field of bits, up to 63 bits.
+func BitFieldUnsigned(bits uint) BitFieldType {
+	return BitFieldType(fmt.Sprintf("u%d", bits))
+}
+
+// BitFieldSigned returns the type of a signed integer field of bits, up to 64 bits.
+func BitFieldSigned(bits uint) BitFieldType {
+	return BitFieldType(fmt.Sprintf("i%d", bits))
+}
+
+// BitFieldOverflow is the behavior of the SET and INCRBY operations of BITFIELD on overflows.
+type BitFieldOverflow string
+
+const (
+	// BitFieldOverflowWrap wraps around the value, it is the default behavior.
+	BitFieldOverflowWrap BitFieldOverflow = "WRAP"
+	// BitFieldOverflowSat saturates the value to the minimum or maximum value of the type.
+	BitFieldOverflowSat BitFieldOverflow = "SAT"
+	// BitFieldOverflowFail fails the operation and its result is nil.
+	BitFieldOverflowFail BitFieldOverflow = "FAIL"
+)
+
+// BitField composes the operations of a BITFIELD command, the result of each operation is returned in order.
+type BitField struct {
+	args redis.Args
+}
+
+// NewBitField returns a new BITFIELD without any operation
+func NewBitField() *BitField {
+	return &BitField{}
+}
+
+// Get returns the field of type t at the bit offset.
+func (bf *BitField) Get(t BitFieldType, offset int64) *BitField {
+	bf.args = bf.args.Add("GET", string(t), offset)
+	return bf
+}
+
+// Set sets the field of type t at the bit offset to value and returns its old value.
+func (bf *BitField) Set(t BitFieldType, offset int64, value int64) *BitField {
+	bf.args = bf.args.Add("SET", string(t), offset, value)
+	return bf
+}
+
+// IncrBy increments the field of type t at the bit offset by increment and returns its new value.
+func (bf *BitField) IncrBy(t BitFieldType, offset int64, increment int64) *BitField {
+	bf.args = bf.args.Add("INCRBY", string(t), offset, increment)
+	return bf
+}
+
+// Overflow sets the overflow behavior of the SET and INCRBY operations which come after it.
+func (bf *BitField) Overflow(overflow BitFieldOverflow) *BitField {
+	bf.args = bf.args.Add("OVERFLOW", string(overflow))
+	return bf
+}
+
+// bitFieldResults scans the results of the BITFIELD operations, nil for the failed overflows
+type bitFieldResults []*int64
+
+// RedisScan satisfies redis.Scanner interface.
+func (br *bitFieldResults) RedisScan(src interface{}) error {
+	values, err := redis.Values(src, nil)
+	if err != nil {
+		return err
+	}
+	results := make([]*int64, len(values))
+	for index, value := range values {
+		if value == nil {
+			continue
+		}
+		result, err := redis.Int64(value, nil)
+		if err != nil {
+			return err
+		}
+		results[index] = &result
+	}
+	*br = results
+	return nil
+}
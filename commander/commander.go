@@ -1,14 +1,60 @@
 package commander
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
 	"github.com/gomodule/redigo/redis"
 )
 
 // Commander provides a means to command redigo easily
 type Commander struct {
-	conn           redis.Conn
-	pendingResults []interface{}
-	err            error
+	conn            redis.Conn
+	pendingResults  []interface{}
+	pendingCommands []string
+	err             error
+	// multi is set when the chain has an open MULTI transaction block which starts at multiIndex
+	multi      bool
+	multiIndex int
+	exec       bool
+	// queued are the commands of the open transaction block which are sent after MULTI by Exec
+	queued []queuedCommand
+	// loads are the SCRIPT LOAD commands of the chain and loaded are the scripts which are loaded by the commander
+	loads  []scriptLoad
+	loaded map[string]bool
+	// blockTimeout is the total timeout of the blocking commands of the chain, blockForever is set when one of them has no timeout
+	blocking     bool
+	blockForever bool
+	blockTimeout time.Duration
+}
+
+// blockReadTimeout is the read timeout of the replies which is added to the timeouts of the blocking commands
+const blockReadTimeout = 5 * time.Second
+
+// readTimeoutKey is the context key of the read timeout which CommitContext passes to DoContext of the connection
+type readTimeoutKey struct{}
+
+// ReadTimeout returns the read timeout of the replies which CommitContext passes with the context to DoContext
+// of the connection for the blocking commands, zero timeout means no read deadline. If ok is false the connection
+// uses its own read timeout. The connections of bluto read the replies with it instead of their read timeout.
+func ReadTimeout(ctx context.Context) (timeout time.Duration, ok bool) {
+	timeout, ok = ctx.Value(readTimeoutKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// ErrTxAborted is returned by Exec when the transaction is not executed because a watched key has been changed.
+var ErrTxAborted = errors.New("bluto: transaction aborted because a watched key has been changed")
+
+// CommandResult is the outcome of a single command of the chain which is reported by CommitReport.
+type CommandResult struct {
+	// Index is the position of the command in the chain.
+	Index int
+	// Name is the name of the redis command.
+	Name string
+	// Err is the error reply of the command or the error of scanning its result, nil on success.
+	Err error
 }
 
 // New returns a new commander
@@ -99,6 +145,89 @@ func (so SetOptionKeepTTL) setOption() []interface{} {
 	return []interface{}{"KEEPTTL"}
 }
 
+// SetOptionEXAT (Redis>=6.2) (EXAT timestamp-seconds) Set the specified Unix time at which the key will expire, in seconds.
+type SetOptionEXAT struct {
+	EXAT uint64
+}
+
+// setOption satisfies setOption interface.
+func (so SetOptionEXAT) setOption() []interface{} {
+	return []interface{}{"EXAT", so.EXAT}
+}
+
+// SetOptionPXAT (Redis>=6.2) (PXAT timestamp-milliseconds) Set the specified Unix time at which the key will expire, in milliseconds.
+type SetOptionPXAT struct {
+	PXAT uint64
+}
+
+// setOption satisfies setOption interface.
+func (so SetOptionPXAT) setOption() []interface{} {
+	return []interface{}{"PXAT", so.PXAT}
+}
+
+// SetOptionGET (Redis>=6.2) Return the old value stored at key, or nil when key did not exist.
+type SetOptionGET struct {
+}
+
+// setOption satisfies setOption interface.
+func (so SetOptionGET) setOption() []interface{} {
+	return []interface{}{"GET"}
+}
+
+// GetExOption define option interface for redis GETEX command.
+type GetExOption interface {
+	getExOption() []interface{}
+}
+
+// GetExOptionEX (EX seconds) Set the specified expire time, in seconds.
+type GetExOptionEX struct {
+	EX uint64
+}
+
+// getExOption satisfies getExOption interface.
+func (gx GetExOptionEX) getExOption() []interface{} {
+	return []interface{}{"EX", gx.EX}
+}
+
+// GetExOptionPX (PX milliseconds) Set the specified expire time, in milliseconds.
+type GetExOptionPX struct {
+	PX uint64
+}
+
+// getExOption satisfies getExOption interface.
+func (gx GetExOptionPX) getExOption() []interface{} {
+	return []interface{}{"PX", gx.PX}
+}
+
+// GetExOptionEXAT (EXAT timestamp-seconds) Set the specified Unix time at which the key will expire, in seconds.
+type GetExOptionEXAT struct {
+	EXAT uint64
+}
+
+// getExOption satisfies getExOption interface.
+func (gx GetExOptionEXAT) getExOption() []interface{} {
+	return []interface{}{"EXAT", gx.EXAT}
+}
+
+// GetExOptionPXAT (PXAT timestamp-milliseconds) Set the specified Unix time at which the key will expire, in milliseconds.
+type GetExOptionPXAT struct {
+	PXAT uint64
+}
+
+// getExOption satisfies getExOption interface.
+func (gx GetExOptionPXAT) getExOption() []interface{} {
+	return []interface{}{"PXAT", gx.PXAT}
+}
+
+// GetExOptionPersist Remove the time to live associated with the key.
+type GetExOptionPersist struct {
+}
+
+// getExOption satisfies getExOption interface.
+func (gx GetExOptionPersist) getExOption() []interface{} {
+	return []interface{}{"PERSIST"}
+}
+
 // XAddOption define option interface for redis XADD command.
 type XAddOption interface {
 	xaddOption() []interface{}
@@ -120,6 +249,40 @@ func (xo XAddOptionMaxLen) xaddOption() []interface{} {
 	return option
 }
 
+// XAddOptionMinID (Redis>=6.2) evicts the entries with IDs lower than MinID from the stream.
+type XAddOptionMinID struct {
+	MinID       string
+	Approximate bool
+}
+
+// xaddOption satisfies xaddOption interface.
+func (xo XAddOptionMinID) xaddOption() []interface{} {
+	option := []interface{}{"MINID"}
+	if xo.Approximate {
+		option = append(option, "~")
+	}
+	option = append(option, xo.MinID)
+	return option
+}
+
+// XAddOptionLimit (Redis>=6.2) limits the number of entries evicted by an approximate MAXLEN or MINID.
+type XAddOptionLimit struct {
+	Limit uint64
+}
+
+// xaddOption satisfies xaddOption interface.
+func (xo XAddOptionLimit) xaddOption() []interface{} {
+	return []interface{}{"LIMIT", xo.Limit}
+}
+
+// XAddOptionNoMkStream (Redis>=6.2) doesn't create the stream if it does not exist, the result is left empty then.
+type XAddOptionNoMkStream struct{}
+
+// xaddOption satisfies xaddOption interface.
+func (xo XAddOptionNoMkStream) xaddOption() []interface{} {
+	return []interface{}{"NOMKSTREAM"}
+}
+
 // XReadOption define option interface for redis XREAD command.
 type XReadOption interface {
 	xreadOption() []interface{}
@@ -273,225 +436,1471 @@ func (xo XPendingOptionConsumer) xpendingOption() []interface{} {
 	return []interface{}{xo.Consumer}
 }
 
-// Command commands the redis connection
-func (c *Commander) Command(result interface{}, name string, args ...interface{}) *Commander {
-	// if there has been an error don't do anything
-	if c.err != nil {
-		return c
-	}
-	// add query result to pending result list
-	c.pendingResults = append(c.pendingResults, result)
-	// send the command to buffer
-	c.err = c.conn.Send(name, args...)
-	return c
+// XRangeOption define option interface for redis XRANGE and XREVRANGE commands.
+type XRangeOption interface {
+	xrangeOption() []interface{}
 }
 
-// Commit returns the results of all the commands
-func (c *Commander) Commit() error {
-	defer c.conn.Close()
-	// if there has been an error don't do anything
-	if c.err != nil {
-		return c.err
-	}
-	// execute the commands
-	results, err := redis.Values(c.conn.Do(""))
-	if err != nil {
-		return err
-	}
-	// evaluate all pending results
-	_, err = redis.Scan(results, c.pendingResults...)
-	if err != nil {
-		return err
-	}
-	return nil
+// XRangeOptionCount set maximum return count entries.
+type XRangeOptionCount struct {
+	Count uint64
 }
 
-// Select the Redis logical database having the specified zero-based numeric index.
-func (c *Commander) Select(result *string, index int) *Commander {
-	return c.Command(result, "SELECT", index)
+// xrangeOption satisfies xrangeOption interface.
+func (xo XRangeOptionCount) xrangeOption() []interface{} {
+	return []interface{}{"COUNT", xo.Count}
 }
 
-// Get the value of key. If the key does not exist the special value nil is returned.
-func (c *Commander) Get(result interface{}, key string) *Commander {
-	return c.Command(result, "GET", key)
+// XTrimOption define option interface for redis XTRIM command.
+type XTrimOption interface {
+	xtrimOption() []interface{}
 }
 
-// Expire set a timeout on key. After the timeout has expired, the key will automatically be deleted.
-func (c *Commander) Expire(result *bool, key string, seconds int) *Commander {
-	return c.Command(result, "EXPIRE", key, seconds)
+// XTrimOptionMaxLen trims the stream to a maximum number of entries.
+type XTrimOptionMaxLen struct {
+	MaxLen      uint64
+	Approximate bool
 }
 
-// Del removes the specified keys. A key is ignored if it does not exist.
-func (c *Commander) Del(result *int, keys ...string) *Commander {
-	iKeys := make([]interface{}, len(keys))
-	for i := range keys {
-		iKeys[i] = keys[i]
+// xtrimOption satisfies xtrimOption interface.
+func (xo XTrimOptionMaxLen) xtrimOption() []interface{} {
+	option := []interface{}{"MAXLEN"}
+	if xo.Approximate {
+		option = append(option, "~")
 	}
-	return c.Command(result, "DEL", iKeys...)
+	option = append(option, xo.MaxLen)
+	return option
 }
 
-// Decr decrements the number stored at key by one. If the key does not exist, it is set to 0.
-func (c *Commander) Decr(result *int64, key string) *Commander {
-	return c.Command(result, "DECR", key)
+// XTrimOptionMinID (Redis>=6.2) evicts the entries with IDs lower than MinID from the stream.
+type XTrimOptionMinID struct {
+	MinID       string
+	Approximate bool
 }
 
-// Incr Increments the number stored at key by one. If the key does not exist, it is set to 0.
-func (c *Commander) Incr(result *int64, key string) *Commander {
-	return c.Command(result, "INCR", key)
+// xtrimOption satisfies xtrimOption interface.
+func (xo XTrimOptionMinID) xtrimOption() []interface{} {
+	option := []interface{}{"MINID"}
+	if xo.Approximate {
+		option = append(option, "~")
+	}
+	option = append(option, xo.MinID)
+	return option
 }
 
-// FlushAll delete all the keys of all the existing databases, not just the currently selected one.
-func (c *Commander) FlushAll(result *string, options ...FlushAllOption) *Commander {
-	cmd := redis.Args{}
-	for _, option := range options {
-		cmd = cmd.Add(option.flushAllOption()...)
-	}
-	return c.Command(result, "FLUSHALL", cmd...)
+// XTrimOptionLimit (Redis>=6.2) limits the number of entries evicted by an approximate MAXLEN or MINID.
+type XTrimOptionLimit struct {
+	Limit uint64
 }
 
-// Keys returns all keys matching pattern.
-func (c *Commander) Keys(result *[]string, pattern string) *Commander {
-	return c.Command(result, "KEYS", pattern)
+// xtrimOption satisfies xtrimOption interface.
+func (xo XTrimOptionLimit) xtrimOption() []interface{} {
+	return []interface{}{"LIMIT", xo.Limit}
 }
 
-// Ping returns PONG if no argument is provided, otherwise return a copy of the argument as a bulk.
-func (c *Commander) Ping(result *string, options ...PingOption) *Commander {
-	cmd := redis.Args{}
-	for _, option := range options {
-		cmd = cmd.Add(option.pingOption()...)
-	}
-	return c.Command(result, "PING", cmd...)
+// XInfoStreamOption define option interface for redis XINFO STREAM command.
+type XInfoStreamOption interface {
+	xinfoStreamOption() []interface{}
 }
 
-// Set key to hold the string value. If key already holds a value, it is overwritten.
-func (c *Commander) Set(result *string, key string, value interface{}, options ...SetOption) *Commander {
-	cmd := redis.Args{}
-	cmd = cmd.Add(key).Add(value)
-	for _, option := range options {
-		cmd = cmd.Add(option.setOption()...)
-	}
-	return c.Command(result, "SET", cmd...)
+// XInfoStreamOptionFull returns the entries, groups and consumers of the stream, Count limits the
+// number of the returned entries and pending entries, 0 means the default of the server.
+type XInfoStreamOptionFull struct {
+	Count uint64
 }
 
-// XAdd appends the specified stream entry to the stream at the specified key.
-func (c *Commander) XAdd(result *string, streamName, streamID string, fields interface{}, options ...XAddOption) *Commander {
-	cmd := redis.Args{}.Add(streamName)
-	for _, option := range options {
-		cmd = cmd.Add(option.xaddOption()...)
+// xinfoStreamOption satisfies xinfoStreamOption interface.
+func (xo XInfoStreamOptionFull) xinfoStreamOption() []interface{} {
+	option := []interface{}{"FULL"}
+	if xo.Count > 0 {
+		option = append(option, "COUNT", xo.Count)
 	}
-	cmd = cmd.Add(streamID).AddFlat(fields)
-	return c.Command(
-		result,
-		"XADD",
-		cmd...,
-	)
+	return option
 }
 
-// XGroupCreate is used in order to manage the consumer groups associated with a stream data structure.
-func (c *Commander) XGroupCreate(result *string, streamName, groupName, streamID string, options ...XGroupCreateOption) *Commander {
-	cmd := redis.Args{}.Add("CREATE").Add(streamName).Add(groupName).Add(streamID)
-	for _, option := range options {
-		cmd = cmd.Add(option.xgroupCreateOption()...)
-	}
-	return c.Command(
-		result,
-		"XGROUP",
-		cmd...,
-	)
+// XAutoClaimOption define option interface for redis XAUTOCLAIM command.
+type XAutoClaimOption interface {
+	xautoClaimOption() []interface{}
 }
 
-// XGroupDestroy is used in order to manage the consumer groups associated with a stream data structure.
-func (c *Commander) XGroupDestroy(result *int, streamName, groupName string) *Commander {
-	cmd := redis.Args{}.Add("DESTROY").Add(streamName).Add(groupName)
-	return c.Command(
-		result,
-		"XGROUP",
-		cmd...,
-	)
+// XAutoClaimOptionCount set maximum number of claimed entries.
+type XAutoClaimOptionCount struct {
+	Count uint64
 }
 
-// XGroupDelConsumer is used in order to manage the consumer groups associated with a stream data structure.
-func (c *Commander) XGroupDelConsumer(result *int, streamName, groupName, consumerName string) *Commander {
-	cmd := redis.Args{}.Add("DELCONSUMER").Add(streamName).Add(groupName).Add(consumerName)
-	return c.Command(
-		result,
-		"XGROUP",
-		cmd...,
-	)
+// xautoClaimOption satisfies xautoClaimOption interface.
+func (xo XAutoClaimOptionCount) xautoClaimOption() []interface{} {
+	return []interface{}{"COUNT", xo.Count}
 }
 
-// XRead read data from one or multiple streams, only returning entries with an ID greater than the last received ID reported by the caller.
-func (c *Commander) XRead(result interface{}, streamList, idList []string, options ...XReadOption) *Commander {
-	cmd := redis.Args{}
-	for _, option := range options {
-		cmd = cmd.Add(option.xreadOption()...)
-	}
-	cmd = cmd.Add("STREAMS")
-	for _, stream := range streamList {
-		cmd = cmd.Add(stream)
-	}
-	for _, id := range idList {
-		cmd = cmd.Add(id)
-	}
-	return c.Command(
-		result,
-		"XREAD",
-		cmd...,
-	)
+// XAutoClaimOptionJustID return just an array of IDs of messages successfully claimed, without returning the actual message.
+type XAutoClaimOptionJustID struct {
 }
 
-// XReadGroup s a special version of the XREAD command with support for consumer groups.
-func (c *Commander) XReadGroup(result interface{}, groupName, consumerName string, streamList, idList []string, options ...XReadGroupOption) *Commander {
-	cmd := redis.Args{}
-	cmd = cmd.Add("GROUP").Add(groupName).Add(consumerName)
-	for _, option := range options {
-		cmd = cmd.Add(option.xreadGroupOption()...)
-	}
-	cmd = cmd.Add("STREAMS")
-	for _, stream := range streamList {
-		cmd = cmd.Add(stream)
-	}
-	for _, id := range idList {
-		cmd = cmd.Add(id)
-	}
-	return c.Command(
-		result,
-		"XREADGROUP",
-		cmd...,
-	)
+// xautoClaimOption satisfies xautoClaimOption interface.
+func (xo XAutoClaimOptionJustID) xautoClaimOption() []interface{} {
+	return []interface{}{"JUSTID"}
 }
 
-// XAck  removes one or multiple messages from the pending entries list (PEL) of a stream consumer group.
-func (c *Commander) XAck(result interface{}, streamName, groupName string, idList []string) *Commander {
-	cmd := redis.Args{}
-	cmd = cmd.Add(streamName)
-	cmd = cmd.Add(groupName)
-	for _, id := range idList {
-		cmd = cmd.Add(id)
-	}
-	return c.Command(
-		result,
-		"XACK",
-		cmd...,
-	)
+// ListDirection is the side of a list which elements are moved from or to.
+type ListDirection string
+
+const (
+	// ListDirectionLeft is the head of the list.
+	ListDirectionLeft ListDirection = "LEFT"
+	// ListDirectionRight is the tail of the list.
+	ListDirectionRight ListDirection = "RIGHT"
+)
+
+// ListPosition is the position of an inserted element relative to the pivot of LINSERT.
+type ListPosition string
+
+const (
+	// ListPositionBefore inserts the element before the pivot.
+	ListPositionBefore ListPosition = "BEFORE"
+	// ListPositionAfter inserts the element after the pivot.
+	ListPositionAfter ListPosition = "AFTER"
+)
+
+// LPopOption define option interface for redis LPOP command.
+type LPopOption interface {
+	lpopOption() []interface{}
 }
 
-// XPending fetching data from a stream via a consumer group, and not acknowledging such data, has the effect of creating pending entries.
-func (c *Commander) XPending(result interface{}, streamName, groupName string, options ...XPendingOption) *Commander {
-	cmd := redis.Args{}
-	cmd = cmd.Add(streamName)
-	cmd = cmd.Add(groupName)
-	for _, option := range options {
-		cmd = cmd.Add(option.xpendingOption()...)
-	}
-	return c.Command(
-		result,
-		"XPENDING",
-		cmd...,
-	)
+// LPopOptionCount (Redis>=6.2) pops up to count elements instead of a single one.
+type LPopOptionCount struct {
+	Count uint64
 }
 
-// XClaim this command changes the ownership of a pending message, so that the new owner is the consumer specified as the command argument.
+// lpopOption satisfies lpopOption interface.
+func (lo LPopOptionCount) lpopOption() []interface{} {
+	return []interface{}{lo.Count}
+}
+
+// RPopOption define option interface for redis RPOP command.
+type RPopOption interface {
+	rpopOption() []interface{}
+}
+
+// RPopOptionCount (Redis>=6.2) pops up to count elements instead of a single one.
+type RPopOptionCount struct {
+	Count uint64
+}
+
+// rpopOption satisfies rpopOption interface.
+func (ro RPopOptionCount) rpopOption() []interface{} {
+	return []interface{}{ro.Count}
+}
+
+// LPosOption define option interface for redis LPOS command.
+type LPosOption interface {
+	lposOption() []interface{}
+}
+
+// LPosOptionRank skips the first rank-1 matches, a negative rank searches from the tail of the list.
+type LPosOptionRank struct {
+	Rank int64
+}
+
+// lposOption satisfies lposOption interface.
+func (lo LPosOptionRank) lposOption() []interface{} {
+	return []interface{}{"RANK", lo.Rank}
+}
+
+// LPosOptionCount returns the positions of the first count matches, 0 means all the matches.
+type LPosOptionCount struct {
+	Count uint64
+}
+
+// lposOption satisfies lposOption interface.
+func (lo LPosOptionCount) lposOption() []interface{} {
+	return []interface{}{"COUNT", lo.Count}
+}
+
+// LPosOptionMaxLen compares at most maxlen elements of the list, 0 means all the elements.
+type LPosOptionMaxLen struct {
+	MaxLen uint64
+}
+
+// lposOption satisfies lposOption interface.
+func (lo LPosOptionMaxLen) lposOption() []interface{} {
+	return []interface{}{"MAXLEN", lo.MaxLen}
+}
+
+// BLMPopOption define option interface for redis BLMPOP command.
+type BLMPopOption interface {
+	blmpopOption() []interface{}
+}
+
+// BLMPopOptionCount pops up to count elements instead of a single one.
+type BLMPopOptionCount struct {
+	Count uint64
+}
+
+// blmpopOption satisfies blmpopOption interface.
+func (bo BLMPopOptionCount) blmpopOption() []interface{} {
+	return []interface{}{"COUNT", bo.Count}
+}
+
+// SInterCardOption define option interface for redis SINTERCARD command.
+type SInterCardOption interface {
+	sinterCardOption() []interface{}
+}
+
+// SInterCardOptionLimit stops counting when the cardinality reaches limit, 0 means no limit.
+type SInterCardOptionLimit struct {
+	Limit uint64
+}
+
+// sinterCardOption satisfies sinterCardOption interface.
+func (so SInterCardOptionLimit) sinterCardOption() []interface{} {
+	return []interface{}{"LIMIT", so.Limit}
+}
+
+// SPopOption define option interface for redis SPOP command.
+type SPopOption interface {
+	spopOption() []interface{}
+}
+
+// SPopOptionCount pops up to count members instead of a single one.
+type SPopOptionCount struct {
+	Count uint64
+}
+
+// spopOption satisfies spopOption interface.
+func (so SPopOptionCount) spopOption() []interface{} {
+	return []interface{}{so.Count}
+}
+
+// SRandMemberOption define option interface for redis SRANDMEMBER command.
+type SRandMemberOption interface {
+	srandMemberOption() []interface{}
+}
+
+// SRandMemberOptionCount returns up to count distinct members, a negative count may return the same member multiple times.
+type SRandMemberOptionCount struct {
+	Count int64
+}
+
+// srandMemberOption satisfies srandMemberOption interface.
+func (so SRandMemberOptionCount) srandMemberOption() []interface{} {
+	return []interface{}{so.Count}
+}
+
+// ZAggregate is the function which aggregates the scores of a member in ZUNION and ZINTER.
+type ZAggregate string
+
+const (
+	// ZAggregateSum sums the scores of the member.
+	ZAggregateSum ZAggregate = "SUM"
+	// ZAggregateMin uses the minimum score of the member.
+	ZAggregateMin ZAggregate = "MIN"
+	// ZAggregateMax uses the maximum score of the member.
+	ZAggregateMax ZAggregate = "MAX"
+)
+
+// ZAddOption define option interface for redis ZADD command.
+type ZAddOption interface {
+	zaddOption() []interface{}
+}
+
+// ZAddOptionNX Only add new members, don't update the scores of the existing members.
+type ZAddOptionNX struct {
+}
+
+// zaddOption satisfies zaddOption interface.
+func (zo ZAddOptionNX) zaddOption() []interface{} {
+	return []interface{}{"NX"}
+}
+
+// ZAddOptionXX Only update the scores of the existing members, don't add new members.
+type ZAddOptionXX struct {
+}
+
+// zaddOption satisfies zaddOption interface.
+func (zo ZAddOptionXX) zaddOption() []interface{} {
+	return []interface{}{"XX"}
+}
+
+// ZAddOptionGT (Redis>=6.2) Only update the scores of the existing members if the new score is greater than the current score.
+type ZAddOptionGT struct {
+}
+
+// zaddOption satisfies zaddOption interface.
+func (zo ZAddOptionGT) zaddOption() []interface{} {
+	return []interface{}{"GT"}
+}
+
+// ZAddOptionLT (Redis>=6.2) Only update the scores of the existing members if the new score is less than the current score.
+type ZAddOptionLT struct {
+}
+
+// zaddOption satisfies zaddOption interface.
+func (zo ZAddOptionLT) zaddOption() []interface{} {
+	return []interface{}{"LT"}
+}
+
+// ZAddOptionCH returns the number of the changed members instead of the number of the added members.
+type ZAddOptionCH struct {
+}
+
+// zaddOption satisfies zaddOption interface.
+func (zo ZAddOptionCH) zaddOption() []interface{} {
+	return []interface{}{"CH"}
+}
+
+// ZAddOptionINCR increments the score of the member like ZINCRBY and returns the new score, or nil if the member is not updated.
+type ZAddOptionINCR struct {
+}
+
+// zaddOption satisfies zaddOption interface.
+func (zo ZAddOptionINCR) zaddOption() []interface{} {
+	return []interface{}{"INCR"}
+}
+
+// ZRangeOption define option interface for redis ZRANGE command.
+type ZRangeOption interface {
+	zrangeOption() []interface{}
+}
+
+// ZRangeStoreOption define option interface for redis ZRANGESTORE command.
+type ZRangeStoreOption interface {
+	zrangeStoreOption() []interface{}
+}
+
+// ZRangeOptionByScore the start and stop are the min and max scores of the range.
+type ZRangeOptionByScore struct {
+}
+
+// zrangeOption satisfies zrangeOption interface.
+func (zo ZRangeOptionByScore) zrangeOption() []interface{} {
+	return []interface{}{"BYSCORE"}
+}
+
+// zrangeStoreOption satisfies zrangeStoreOption interface.
+func (zo ZRangeOptionByScore) zrangeStoreOption() []interface{} {
+	return zo.zrangeOption()
+}
+
+// ZRangeOptionByLex the start and stop are the min and max members of the range in lexicographical order.
+type ZRangeOptionByLex struct {
+}
+
+// zrangeOption satisfies zrangeOption interface.
+func (zo ZRangeOptionByLex) zrangeOption() []interface{} {
+	return []interface{}{"BYLEX"}
+}
+
+// zrangeStoreOption satisfies zrangeStoreOption interface.
+func (zo ZRangeOptionByLex) zrangeStoreOption() []interface{} {
+	return zo.zrangeOption()
+}
+
+// ZRangeOptionRev reverses the order of the range, the start must be greater than the stop with BYSCORE or BYLEX.
+type ZRangeOptionRev struct {
+}
+
+// zrangeOption satisfies zrangeOption interface.
+func (zo ZRangeOptionRev) zrangeOption() []interface{} {
+	return []interface{}{"REV"}
+}
+
+// zrangeStoreOption satisfies zrangeStoreOption interface.
+func (zo ZRangeOptionRev) zrangeStoreOption() []interface{} {
+	return zo.zrangeOption()
+}
+
+// ZRangeOptionLimit returns count members after skipping offset members, it is only valid with BYSCORE or BYLEX.
+type ZRangeOptionLimit struct {
+	Offset int64
+	Count  int64
+}
+
+// zrangeOption satisfies zrangeOption interface.
+func (zo ZRangeOptionLimit) zrangeOption() []interface{} {
+	return []interface{}{"LIMIT", zo.Offset, zo.Count}
+}
+
+// zrangeStoreOption satisfies zrangeStoreOption interface.
+func (zo ZRangeOptionLimit) zrangeStoreOption() []interface{} {
+	return zo.zrangeOption()
+}
+
+// ZRangeOptionWithScores returns the scores of the members after each member.
+type ZRangeOptionWithScores struct {
+}
+
+// zrangeOption satisfies zrangeOption interface.
+func (zo ZRangeOptionWithScores) zrangeOption() []interface{} {
+	return []interface{}{"WITHSCORES"}
+}
+
+// ZPopMinOption define option interface for redis ZPOPMIN command.
+type ZPopMinOption interface {
+	zpopMinOption() []interface{}
+}
+
+// ZPopMinOptionCount pops up to count members instead of a single one.
+type ZPopMinOptionCount struct {
+	Count uint64
+}
+
+// zpopMinOption satisfies zpopMinOption interface.
+func (zo ZPopMinOptionCount) zpopMinOption() []interface{} {
+	return []interface{}{zo.Count}
+}
+
+// ZPopMaxOption define option interface for redis ZPOPMAX command.
+type ZPopMaxOption interface {
+	zpopMaxOption() []interface{}
+}
+
+// ZPopMaxOptionCount pops up to count members instead of a single one.
+type ZPopMaxOptionCount struct {
+	Count uint64
+}
+
+// zpopMaxOption satisfies zpopMaxOption interface.
+func (zo ZPopMaxOptionCount) zpopMaxOption() []interface{} {
+	return []interface{}{zo.Count}
+}
+
+// ZCombineOption define option interface for redis ZUNION and ZINTER commands.
+type ZCombineOption interface {
+	zcombineOption() []interface{}
+}
+
+// ZCombineOptionWeights multiplies the scores of each sorted set by its weight.
+type ZCombineOptionWeights struct {
+	Weights []float64
+}
+
+// zcombineOption satisfies zcombineOption interface.
+func (zo ZCombineOptionWeights) zcombineOption() []interface{} {
+	return redis.Args{}.Add("WEIGHTS").AddFlat(zo.Weights)
+}
+
+// ZCombineOptionAggregate sets the function which aggregates the scores of a member, it is SUM by default.
+type ZCombineOptionAggregate struct {
+	Aggregate ZAggregate
+}
+
+// zcombineOption satisfies zcombineOption interface.
+func (zo ZCombineOptionAggregate) zcombineOption() []interface{} {
+	return []interface{}{"AGGREGATE", string(zo.Aggregate)}
+}
+
+// ZCombineOptionWithScores returns the scores of the members after each member.
+type ZCombineOptionWithScores struct {
+}
+
+// zcombineOption satisfies zcombineOption interface.
+func (zo ZCombineOptionWithScores) zcombineOption() []interface{} {
+	return []interface{}{"WITHSCORES"}
+}
+
+// ZDiffOption define option interface for redis ZDIFF command.
+type ZDiffOption interface {
+	zdiffOption() []interface{}
+}
+
+// ZDiffOptionWithScores returns the scores of the members after each member.
+type ZDiffOptionWithScores struct {
+}
+
+// zdiffOption satisfies zdiffOption interface.
+func (zo ZDiffOptionWithScores) zdiffOption() []interface{} {
+	return []interface{}{"WITHSCORES"}
+}
+
+// BitUnit is the unit of the range of the bitmap commands.
+type BitUnit string
+
+const (
+	// BitUnitByte is a range of bytes, it is the default unit.
+	BitUnitByte BitUnit = "BYTE"
+	// BitUnitBit (Redis>=7.0) is a range of bits.
+	BitUnitBit BitUnit = "BIT"
+)
+
+// BitOperation is the bitwise operation of BITOP.
+type BitOperation string
+
+const (
+	// BitOperationAnd is the bitwise AND of the keys.
+	BitOperationAnd BitOperation = "AND"
+	// BitOperationOr is the bitwise OR of the keys.
+	BitOperationOr BitOperation = "OR"
+	// BitOperationXor is the bitwise XOR of the keys.
+	BitOperationXor BitOperation = "XOR"
+	// BitOperationNot is the bitwise NOT of a single key.
+	BitOperationNot BitOperation = "NOT"
+)
+
+// BitCountOption define option interface for redis BITCOUNT command.
+type BitCountOption interface {
+	bitCountOption() []interface{}
+}
+
+// BitCountOptionRange counts the set bits between start and end, inclusive, the Unit is BYTE if it is empty.
+type BitCountOptionRange struct {
+	Start int64
+	End   int64
+	Unit  BitUnit
+}
+
+// bitCountOption satisfies bitCountOption interface.
+func (bo BitCountOptionRange) bitCountOption() []interface{} {
+	option := []interface{}{bo.Start, bo.End}
+	if bo.Unit != "" {
+		option = append(option, string(bo.Unit))
+	}
+	return option
+}
+
+// BitPosOption define option interface for redis BITPOS command.
+type BitPosOption interface {
+	bitPosOption() []interface{}
+}
+
+// BitPosOptionStart searches from the byte at start to the end of the string.
+type BitPosOptionStart struct {
+	Start int64
+}
+
+// bitPosOption satisfies bitPosOption interface.
+func (bo BitPosOptionStart) bitPosOption() []interface{} {
+	return []interface{}{bo.Start}
+}
+
+// BitPosOptionRange searches between start and end, inclusive, the Unit is BYTE if it is empty.
+type BitPosOptionRange struct {
+	Start int64
+	End   int64
+	Unit  BitUnit
+}
+
+// bitPosOption satisfies bitPosOption interface.
+func (bo BitPosOptionRange) bitPosOption() []interface{} {
+	option := []interface{}{bo.Start, bo.End}
+	if bo.Unit != "" {
+		option = append(option, string(bo.Unit))
+	}
+	return option
+}
+
+// GeoUnit is the unit of the distances of the geospatial commands.
+type GeoUnit string
+
+const (
+	// GeoUnitMeters is the unit of meters, it is the default unit.
+	GeoUnitMeters GeoUnit = "m"
+	// GeoUnitKilometers is the unit of kilometers.
+	GeoUnitKilometers GeoUnit = "km"
+	// GeoUnitMiles is the unit of miles.
+	GeoUnitMiles GeoUnit = "mi"
+	// GeoUnitFeet is the unit of feet.
+	GeoUnitFeet GeoUnit = "ft"
+)
+
+// arg returns the unit argument of the command, an empty unit is the default unit of meters
+func (gu GeoUnit) arg() string {
+	if gu == "" {
+		return string(GeoUnitMeters)
+	}
+	return string(gu)
+}
+
+// GeoAddOption define option interface for redis GEOADD command.
+type GeoAddOption interface {
+	geoAddOption() []interface{}
+}
+
+// GeoAddOptionNX Only add new members, don't update the existing members.
+type GeoAddOptionNX struct {
+}
+
+// geoAddOption satisfies geoAddOption interface.
+func (gx GeoAddOptionNX) geoAddOption() []interface{} {
+	return []interface{}{"NX"}
+}
+
+// GeoAddOptionXX Only update the existing members, don't add new members.
+type GeoAddOptionXX struct {
+}
+
+// geoAddOption satisfies geoAddOption interface.
+func (gx GeoAddOptionXX) geoAddOption() []interface{} {
+	return []interface{}{"XX"}
+}
+
+// GeoAddOptionCH returns the number of the changed members instead of the number of the added members.
+type GeoAddOptionCH struct {
+}
+
+// geoAddOption satisfies geoAddOption interface.
+func (gx GeoAddOptionCH) geoAddOption() []interface{} {
+	return []interface{}{"CH"}
+}
+
+// GeoDistOption define option interface for redis GEODIST command.
+type GeoDistOption interface {
+	geoDistOption() []interface{}
+}
+
+// GeoDistOptionUnit returns the distance in the unit instead of meters.
+type GeoDistOptionUnit struct {
+	Unit GeoUnit
+}
+
+// geoDistOption satisfies geoDistOption interface.
+func (gx GeoDistOptionUnit) geoDistOption() []interface{} {
+	return []interface{}{gx.Unit.arg()}
+}
+
+// GeoFrom is the center of the area of GEOSEARCH and GEOSEARCHSTORE.
+type GeoFrom interface {
+	geoFrom() []interface{}
+}
+
+// GeoFromMember uses the position of an existing member of the sorted set as the center.
+type GeoFromMember struct {
+	Member string
+}
+
+// geoFrom satisfies geoFrom interface.
+func (gf GeoFromMember) geoFrom() []interface{} {
+	return []interface{}{"FROMMEMBER", gf.Member}
+}
+
+// GeoFromLonLat uses the longitude and latitude as the center.
+type GeoFromLonLat struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// geoFrom satisfies geoFrom interface.
+func (gf GeoFromLonLat) geoFrom() []interface{} {
+	return []interface{}{"FROMLONLAT", gf.Longitude, gf.Latitude}
+}
+
+// GeoBy is the shape of the area of GEOSEARCH and GEOSEARCHSTORE.
+type GeoBy interface {
+	geoBy() []interface{}
+}
+
+// GeoByRadius searches in a circle of the radius, the Unit is meters if it is empty.
+type GeoByRadius struct {
+	Radius float64
+	Unit   GeoUnit
+}
+
+// geoBy satisfies geoBy interface.
+func (gb GeoByRadius) geoBy() []interface{} {
+	return []interface{}{"BYRADIUS", gb.Radius, gb.Unit.arg()}
+}
+
+// GeoByBox searches in an axis-aligned rectangle of the width and height, the Unit is meters if it is empty.
+type GeoByBox struct {
+	Width  float64
+	Height float64
+	Unit   GeoUnit
+}
+
+// geoBy satisfies geoBy interface.
+func (gb GeoByBox) geoBy() []interface{} {
+	return []interface{}{"BYBOX", gb.Width, gb.Height, gb.Unit.arg()}
+}
+
+// GeoSearchOption define option interface for redis GEOSEARCH command.
+type GeoSearchOption interface {
+	geoSearchOption() []interface{}
+}
+
+// GeoSearchStoreOption define option interface for redis GEOSEARCHSTORE command.
+type GeoSearchStoreOption interface {
+	geoSearchStoreOption() []interface{}
+}
+
+// GeoSearchOptionAsc sorts the members from the nearest to the farthest.
+type GeoSearchOptionAsc struct {
+}
+
+// geoSearchOption satisfies geoSearchOption interface.
+func (gx GeoSearchOptionAsc) geoSearchOption() []interface{} {
+	return []interface{}{"ASC"}
+}
+
+// geoSearchStoreOption satisfies geoSearchStoreOption interface.
+func (gx GeoSearchOptionAsc) geoSearchStoreOption() []interface{} {
+	return gx.geoSearchOption()
+}
+
+// GeoSearchOptionDesc sorts the members from the farthest to the nearest.
+type GeoSearchOptionDesc struct {
+}
+
+// geoSearchOption satisfies geoSearchOption interface.
+func (gx GeoSearchOptionDesc) geoSearchOption() []interface{} {
+	return []interface{}{"DESC"}
+}
+
+// geoSearchStoreOption satisfies geoSearchStoreOption interface.
+func (gx GeoSearchOptionDesc) geoSearchStoreOption() []interface{} {
+	return gx.geoSearchOption()
+}
+
+// GeoSearchOptionCount limits the members to count, with Any the search stops as soon as count members are found.
+type GeoSearchOptionCount struct {
+	Count uint64
+	Any   bool
+}
+
+// geoSearchOption satisfies geoSearchOption interface.
+func (gx GeoSearchOptionCount) geoSearchOption() []interface{} {
+	option := []interface{}{"COUNT", gx.Count}
+	if gx.Any {
+		option = append(option, "ANY")
+	}
+	return option
+}
+
+// geoSearchStoreOption satisfies geoSearchStoreOption interface.
+func (gx GeoSearchOptionCount) geoSearchStoreOption() []interface{} {
+	return gx.geoSearchOption()
+}
+
+// GeoSearchOptionWithCoord returns the longitude and latitude of the members.
+type GeoSearchOptionWithCoord struct {
+}
+
+// geoSearchOption satisfies geoSearchOption interface.
+func (gx GeoSearchOptionWithCoord) geoSearchOption() []interface{} {
+	return []interface{}{"WITHCOORD"}
+}
+
+// GeoSearchOptionWithDist returns the distance of the members from the center in the unit of the search.
+type GeoSearchOptionWithDist struct {
+}
+
+// geoSearchOption satisfies geoSearchOption interface.
+func (gx GeoSearchOptionWithDist) geoSearchOption() []interface{} {
+	return []interface{}{"WITHDIST"}
+}
+
+// GeoSearchOptionWithHash returns the geohash of the members as an integer.
+type GeoSearchOptionWithHash struct {
+}
+
+// geoSearchOption satisfies geoSearchOption interface.
+func (gx GeoSearchOptionWithHash) geoSearchOption() []interface{} {
+	return []interface{}{"WITHHASH"}
+}
+
+// GeoSearchStoreOptionStoreDist stores the distances of the members as their scores instead of their geohashes.
+type GeoSearchStoreOptionStoreDist struct {
+}
+
+// geoSearchStoreOption satisfies geoSearchStoreOption interface.
+func (gx GeoSearchStoreOptionStoreDist) geoSearchStoreOption() []interface{} {
+	return []interface{}{"STOREDIST"}
+}
+
+// ExpireOption define option interface for redis EXPIRE, PEXPIRE, EXPIREAT and PEXPIREAT commands.
+type ExpireOption interface {
+	expireOption() []interface{}
+}
+
+// ExpireOptionNX (Redis>=7.0) Set the expiry only when the key has no expiry.
+type ExpireOptionNX struct {
+}
+
+// expireOption satisfies expireOption interface.
+func (eo ExpireOptionNX) expireOption() []interface{} {
+	return []interface{}{"NX"}
+}
+
+// ExpireOptionXX (Redis>=7.0) Set the expiry only when the key has an existing expiry.
+type ExpireOptionXX struct {
+}
+
+// expireOption satisfies expireOption interface.
+func (eo ExpireOptionXX) expireOption() []interface{} {
+	return []interface{}{"XX"}
+}
+
+// ExpireOptionGT (Redis>=7.0) Set the expiry only when the new expiry is greater than the current one.
+type ExpireOptionGT struct {
+}
+
+// expireOption satisfies expireOption interface.
+func (eo ExpireOptionGT) expireOption() []interface{} {
+	return []interface{}{"GT"}
+}
+
+// ExpireOptionLT (Redis>=7.0) Set the expiry only when the new expiry is less than the current one.
+type ExpireOptionLT struct {
+}
+
+// expireOption satisfies expireOption interface.
+func (eo ExpireOptionLT) expireOption() []interface{} {
+	return []interface{}{"LT"}
+}
+
+// CopyOption define option interface for redis COPY command.
+type CopyOption interface {
+	copyOption() []interface{}
+}
+
+// CopyOptionDB copies the key to the logical database DB instead of the current one.
+type CopyOptionDB struct {
+	DB int
+}
+
+// copyOption satisfies copyOption interface.
+func (co CopyOptionDB) copyOption() []interface{} {
+	return []interface{}{"DB", co.DB}
+}
+
+// CopyOptionReplace removes the destination key before copying the value to it.
+type CopyOptionReplace struct {
+}
+
+// copyOption satisfies copyOption interface.
+func (co CopyOptionReplace) copyOption() []interface{} {
+	return []interface{}{"REPLACE"}
+}
+
+// RestoreOption define option interface for redis RESTORE command.
+type RestoreOption interface {
+	restoreOption() []interface{}
+}
+
+// RestoreOptionReplace replaces the key if it already exists.
+type RestoreOptionReplace struct {
+}
+
+// restoreOption satisfies restoreOption interface.
+func (ro RestoreOptionReplace) restoreOption() []interface{} {
+	return []interface{}{"REPLACE"}
+}
+
+// RestoreOptionAbsTTL the ttl is an absolute Unix time in milliseconds at which the key will expire.
+type RestoreOptionAbsTTL struct {
+}
+
+// restoreOption satisfies restoreOption interface.
+func (ro RestoreOptionAbsTTL) restoreOption() []interface{} {
+	return []interface{}{"ABSTTL"}
+}
+
+// RestoreOptionIdleTime sets the idle time of the key, in seconds.
+type RestoreOptionIdleTime struct {
+	IdleTime uint64
+}
+
+// restoreOption satisfies restoreOption interface.
+func (ro RestoreOptionIdleTime) restoreOption() []interface{} {
+	return []interface{}{"IDLETIME", ro.IdleTime}
+}
+
+// RestoreOptionFreq sets the access frequency of the key.
+type RestoreOptionFreq struct {
+	Freq uint64
+}
+
+// restoreOption satisfies restoreOption interface.
+func (ro RestoreOptionFreq) restoreOption() []interface{} {
+	return []interface{}{"FREQ", ro.Freq}
+}
+
+// HRandFieldOption define option interface for redis HRANDFIELD command.
+type HRandFieldOption interface {
+	hrandFieldOption() []interface{}
+}
+
+// HRandFieldOptionCount returns up to count distinct fields, a negative count may return the same field multiple times.
+// WithValues returns the values of the fields after each field.
+type HRandFieldOptionCount struct {
+	Count      int64
+	WithValues bool
+}
+
+// hrandFieldOption satisfies hrandFieldOption interface.
+func (ho HRandFieldOptionCount) hrandFieldOption() []interface{} {
+	option := []interface{}{ho.Count}
+	if ho.WithValues {
+		option = append(option, "WITHVALUES")
+	}
+	return option
+}
+
+// HSetStructOption define option interface for HSetStruct.
+type HSetStructOption interface {
+	hsetStructOption(fields redis.Args) redis.Args
+}
+
+// HSetStructOptionOmitZero skips the fields of the struct which hold the zero value of their type.
+type HSetStructOptionOmitZero struct{}
+
+// hsetStructOption satisfies hsetStructOption interface.
+func (ho HSetStructOptionOmitZero) hsetStructOption(fields redis.Args) redis.Args {
+	var nonZero redis.Args
+	for index := 0; index+1 < len(fields); index += 2 {
+		value := reflect.ValueOf(fields[index+1])
+		if value.IsValid() && !value.IsZero() {
+			nonZero = append(nonZero, fields[index], fields[index+1])
+		}
+	}
+	return nonZero
+}
+
+// Command commands the redis connection
+func (c *Commander) Command(result interface{}, name string, args ...interface{}) *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	// add query result to pending result list
+	c.pendingResults = append(c.pendingResults, result)
+	c.pendingCommands = append(c.pendingCommands, name)
+	// the commands of the transaction block are sent after the scripts of the block are loaded
+	if c.multi && !c.exec {
+		c.queued = append(c.queued, queuedCommand{name: name, args: args})
+		return c
+	}
+	// send the command to buffer
+	c.err = c.conn.Send(name, args...)
+	return c
+}
+
+// Commit returns the results of all the commands like Exec and releases the connection
+func (c *Commander) Commit() error {
+	defer c.Release()
+	return c.Exec()
+}
+
+// Exec returns the results of all the pending commands and keeps the connection, so that the
+// commander can be used again for the next round of commands until it is released.
+// A transaction block started by Multi is closed and executed by Exec, it returns ErrTxAborted
+// if the transaction is not executed because a watched key has been changed.
+func (c *Commander) Exec() error {
+	// the next round starts with a clean state
+	defer c.reset()
+	// if there has been an error drop the sent commands
+	if c.err != nil {
+		c.discard()
+		return c.err
+	}
+	// close the transaction block
+	err := c.closeMulti()
+	if err != nil {
+		return err
+	}
+	// nothing to execute
+	if len(c.pendingResults) == 0 && !c.multi {
+		return nil
+	}
+	// execute the commands
+	results, err := redis.Values(c.do())
+	if err != nil {
+		return err
+	}
+	results = c.dropLoads(results)
+	// evaluate all pending results
+	err = c.scan(results)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Release returns the connection to the pool, the commander can not be used after it.
+func (c *Commander) Release() error {
+	return c.conn.Close()
+}
+
+// stop clears the state and releases the connection like Commit, and returns the error
+func (c *Commander) stop(err error) error {
+	c.reset()
+	c.Release()
+	return err
+}
+
+// closeMulti sends EXEC to close the open transaction block
+func (c *Commander) closeMulti() error {
+	if !c.multi || c.exec {
+		return nil
+	}
+	c.exec = true
+	// the scripts of the block are loaded before MULTI so that the block is not split by a NOSCRIPT error
+	err := c.sendBlockLoads()
+	if err != nil {
+		return err
+	}
+	err = c.conn.Send("MULTI")
+	if err != nil {
+		return err
+	}
+	for _, command := range c.queued {
+		err = c.conn.Send(command.name, command.args...)
+		if err != nil {
+			return err
+		}
+	}
+	return c.conn.Send("EXEC")
+}
+
+// discard drops the replies of the sent commands and the open transaction block,
+// so that they do not get mixed with the replies of the next round
+func (c *Commander) discard() {
+	if c.multi && !c.exec {
+		// the commands of the open transaction block are not sent yet
+		c.queued = nil
+	}
+	c.conn.Do("")
+}
+
+// reset clears the pending commands and the error of the last round
+func (c *Commander) reset() {
+	c.pendingResults = nil
+	c.pendingCommands = nil
+	c.err = nil
+	c.multi = false
+	c.multiIndex = 0
+	c.exec = false
+	c.queued = nil
+	c.loads = nil
+	c.blocking = false
+	c.blockForever = false
+	c.blockTimeout = 0
+}
+
+// CommitReport returns the results of all the commands like Commit, but a failed command does not fail the
+// whole chain: the results of the succeeded commands are still filled and the outcome of each command is reported.
+// The returned error is only set when the chain could not be executed at all.
+func (c *Commander) CommitReport() ([]CommandResult, error) {
+	defer c.Release()
+	defer c.reset()
+	// if there has been an error drop the sent commands
+	if c.err != nil {
+		c.discard()
+		return nil, c.err
+	}
+	if c.multi {
+		c.discard()
+		return nil, errors.New("bluto: transactions are not supported by CommitReport")
+	}
+	// nothing to execute
+	if len(c.pendingResults) == 0 {
+		return nil, nil
+	}
+	// execute the commands
+	results, err := redis.Values(c.do())
+	if err != nil {
+		return nil, err
+	}
+	results = c.dropLoads(results)
+	if len(results) < len(c.pendingResults) {
+		return nil, errors.New("bluto: missing replies of the commands")
+	}
+	// evaluate each pending result on its own
+	report := make([]CommandResult, len(c.pendingResults))
+	for index, result := range c.pendingResults {
+		report[index] = CommandResult{
+			Index: index,
+			Name:  c.pendingCommands[index],
+		}
+		if replyErr, ok := results[index].(redis.Error); ok {
+			report[index].Err = replyErr
+			continue
+		}
+		_, report[index].Err = redis.Scan(results[index:index+1], result)
+	}
+	return report, nil
+}
+
+// CommitContext returns the results of all the commands like Commit, but it stops waiting
+// for the replies and returns ctx.Err() as soon as the context is cancelled or its deadline is exceeded.
+// If the connection supports redis.ConnWithContext the read of the replies is broken by closing the
+// connection, otherwise the replies are still read in background before the connection is released.
+func (c *Commander) CommitContext(ctx context.Context) error {
+	// if there has been an error drop the sent commands
+	if c.err != nil {
+		c.discard()
+		return c.stop(c.err)
+	}
+	// the context may already be done
+	if err := ctx.Err(); err != nil {
+		return c.stop(err)
+	}
+	// close the transaction block
+	if err := c.closeMulti(); err != nil {
+		return c.stop(err)
+	}
+	// nothing to execute
+	if len(c.pendingResults) == 0 && !c.multi {
+		return c.stop(nil)
+	}
+	// execute the commands in background so that we can stop waiting for them
+	type reply struct {
+		results []interface{}
+		err     error
+	}
+	replies := make(chan reply, 1)
+	go func() {
+		results, err := redis.Values(c.doContext(ctx))
+		if err == nil {
+			results = c.dropLoads(results)
+		}
+		// the connection is released as soon as the replies are read, even if no one is waiting for them
+		c.Release()
+		replies <- reply{results: results, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		// the state is still used by the background commands, the commander can not be used after it anyway
+		return ctx.Err()
+	case r := <-replies:
+		defer c.reset()
+		// a read timeout caused by the context deadline is reported as the context error
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if r.err != nil {
+			return r.err
+		}
+		// evaluate all pending results
+		return c.scan(r.results)
+	}
+}
+
+// doContext flushes the pending commands and reads their replies, the read deadline
+// of the connection is bounded by the deadline of the context if it has one.
+func (c *Commander) doContext(ctx context.Context) (interface{}, error) {
+	if cwc, ok := c.conn.(redis.ConnWithContext); ok {
+		// the read timeout of the blocking commands is passed to the connection with the context
+		if c.blocking {
+			timeout := time.Duration(0)
+			if !c.blockForever {
+				timeout = c.blockTimeout + blockReadTimeout
+			}
+			ctx = context.WithValue(ctx, readTimeoutKey{}, timeout)
+		}
+		return cwc.DoContext(ctx, "")
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return c.do()
+	}
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return nil, context.DeadlineExceeded
+	}
+	cwt, ok := c.conn.(redis.ConnWithTimeout)
+	if !ok {
+		return c.conn.Do("")
+	}
+	return cwt.DoWithTimeout(timeout, "")
+}
+
+// do flushes the pending commands and reads their replies, the read deadline
+// of the connection is extended by the timeouts of the blocking commands.
+func (c *Commander) do() (interface{}, error) {
+	if !c.blocking {
+		return c.conn.Do("")
+	}
+	cwt, ok := c.conn.(redis.ConnWithTimeout)
+	if !ok {
+		return c.conn.Do("")
+	}
+	// zero timeout means no read deadline
+	timeout := time.Duration(0)
+	if !c.blockForever {
+		timeout = c.blockTimeout + blockReadTimeout
+	}
+	return cwt.DoWithTimeout(timeout, "")
+}
+
+// block adds the timeout of a blocking command to the read deadline of the chain, zero timeout blocks forever.
+func (c *Commander) block(timeout time.Duration) {
+	c.blocking = true
+	if timeout <= 0 {
+		c.blockForever = true
+	}
+	c.blockTimeout += timeout
+}
+
+// scan evaluates all pending results from the replies of the commands,
+// the commands of a transaction block get their results from the reply of EXEC.
+func (c *Commander) scan(results []interface{}) error {
+	if !c.multi {
+		_, err := redis.Scan(results, c.pendingResults...)
+		return err
+	}
+	if !c.exec {
+		return errors.New("bluto: MULTI is not closed by Exec")
+	}
+	// the commands before MULTI
+	_, err := redis.Scan(results[:c.multiIndex], c.pendingResults[:c.multiIndex]...)
+	if err != nil {
+		return err
+	}
+	// the reply of EXEC is nil if a watched key has been changed
+	execReply := results[len(results)-1]
+	if execReply == nil {
+		return ErrTxAborted
+	}
+	execResults, err := redis.Values(execReply, nil)
+	if err != nil {
+		return err
+	}
+	_, err = redis.Scan(execResults, c.pendingResults[c.multiIndex:]...)
+	return err
+}
+
+// Multi marks the start of a transaction block, the commands after it are executed atomically by Exec or Commit.
+func (c *Commander) Multi() *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	if c.multi {
+		c.err = errors.New("bluto: MULTI calls can not be nested")
+		return c
+	}
+	// MULTI is sent with the commands of the block by Exec
+	c.multi = true
+	c.multiIndex = len(c.pendingResults)
+	return c
+}
+
+// queuedCommand is a command of the open transaction block.
+type queuedCommand struct {
+	name string
+	args []interface{}
+}
+
+// Eval runs the lua script src with the keys and args.
+func (c *Commander) Eval(result interface{}, src string, keys []string, args ...interface{}) *Commander {
+	return c.Command(result, "EVAL", scriptArgs(src, keys, args)...)
+}
+
+// EvalSha runs the lua script which is loaded on the server with the SHA1 digest sha1.
+func (c *Commander) EvalSha(result interface{}, sha1 string, keys []string, args ...interface{}) *Commander {
+	return c.Command(result, "EVALSHA", scriptArgs(sha1, keys, args)...)
+}
+
+// ScriptLoad loads the lua script src on the server and returns its SHA1 digest.
+func (c *Commander) ScriptLoad(result *string, src string) *Commander {
+	return c.Command(result, "SCRIPT", "LOAD", src)
+}
+
+// RunScript runs the script with EVALSHA, the script is loaded with SCRIPT LOAD ahead of its first run by the commander,
+// or before MULTI if it is in a transaction block, so that it does not fail with NOSCRIPT and the order of the commands is kept.
+func (c *Commander) RunScript(result interface{}, script *Script, keys []string, args ...interface{}) *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	c.loadScript(script)
+	return c.Command(result, "EVALSHA", scriptArgs(script.hash, keys, args)...)
+}
+
+// Publish posts a message to the given channel and returns the number of clients that received the message.
+func (c *Commander) Publish(result *int, channel string, message interface{}) *Commander {
+	return c.Command(result, "PUBLISH", channel, message)
+}
+
+// Select the Redis logical database having the specified zero-based numeric index.
+func (c *Commander) Select(result *string, index int) *Commander {
+	return c.Command(result, "SELECT", index)
+}
+
+// Get the value of key. If the key does not exist the special value nil is returned.
+func (c *Commander) Get(result interface{}, key string) *Commander {
+	return c.Command(result, "GET", key)
+}
+
+// Expire set a timeout on key. After the timeout has expired, the key will automatically be deleted.
+func (c *Commander) Expire(result *bool, key string, seconds int, options ...ExpireOption) *Commander {
+	return c.expire(result, "EXPIRE", key, seconds, options)
+}
+
+// Del removes the specified keys. A key is ignored if it does not exist.
+func (c *Commander) Del(result *int, keys ...string) *Commander {
+	iKeys := make([]interface{}, len(keys))
+	for i := range keys {
+		iKeys[i] = keys[i]
+	}
+	return c.Command(result, "DEL", iKeys...)
+}
+
+// Decr decrements the number stored at key by one. If the key does not exist, it is set to 0.
+func (c *Commander) Decr(result *int64, key string) *Commander {
+	return c.Command(result, "DECR", key)
+}
+
+// Incr Increments the number stored at key by one. If the key does not exist, it is set to 0.
+func (c *Commander) Incr(result *int64, key string) *Commander {
+	return c.Command(result, "INCR", key)
+}
+
+// FlushAll delete all the keys of all the existing databases, not just the currently selected one.
+func (c *Commander) FlushAll(result *string, options ...FlushAllOption) *Commander {
+	cmd := redis.Args{}
+	for _, option := range options {
+		cmd = cmd.Add(option.flushAllOption()...)
+	}
+	return c.Command(result, "FLUSHALL", cmd...)
+}
+
+// Keys returns all keys matching pattern.
+func (c *Commander) Keys(result *[]string, pattern string) *Commander {
+	return c.Command(result, "KEYS", pattern)
+}
+
+// Ping returns PONG if no argument is provided, otherwise return a copy of the argument as a bulk.
+func (c *Commander) Ping(result *string, options ...PingOption) *Commander {
+	cmd := redis.Args{}
+	for _, option := range options {
+		cmd = cmd.Add(option.pingOption()...)
+	}
+	return c.Command(result, "PING", cmd...)
+}
+
+// Set key to hold the string value. If key already holds a value, it is overwritten.
+func (c *Commander) Set(result *string, key string, value interface{}, options ...SetOption) *Commander {
+	cmd := redis.Args{}
+	cmd = cmd.Add(key).Add(value)
+	for _, option := range options {
+		cmd = cmd.Add(option.setOption()...)
+	}
+	return c.Command(result, "SET", cmd...)
+}
+
+// XAdd appends the specified stream entry to the stream at the specified key.
+func (c *Commander) XAdd(result *string, streamName, streamID string, fields interface{}, options ...XAddOption) *Commander {
+	cmd := redis.Args{}.Add(streamName)
+	for _, option := range options {
+		cmd = cmd.Add(option.xaddOption()...)
+	}
+	cmd = cmd.Add(streamID).AddFlat(fields)
+	return c.Command(
+		result,
+		"XADD",
+		cmd...,
+	)
+}
+
+// XGroupCreate is used in order to manage the consumer groups associated with a stream data structure.
+func (c *Commander) XGroupCreate(result *string, streamName, groupName, streamID string, options ...XGroupCreateOption) *Commander {
+	cmd := redis.Args{}.Add("CREATE").Add(streamName).Add(groupName).Add(streamID)
+	for _, option := range options {
+		cmd = cmd.Add(option.xgroupCreateOption()...)
+	}
+	return c.Command(
+		result,
+		"XGROUP",
+		cmd...,
+	)
+}
+
+// XGroupDestroy is used in order to manage the consumer groups associated with a stream data structure.
+func (c *Commander) XGroupDestroy(result *int, streamName, groupName string) *Commander {
+	cmd := redis.Args{}.Add("DESTROY").Add(streamName).Add(groupName)
+	return c.Command(
+		result,
+		"XGROUP",
+		cmd...,
+	)
+}
+
+// XGroupDelConsumer is used in order to manage the consumer groups associated with a stream data structure.
+func (c *Commander) XGroupDelConsumer(result *int, streamName, groupName, consumerName string) *Commander {
+	cmd := redis.Args{}.Add("DELCONSUMER").Add(streamName).Add(groupName).Add(consumerName)
+	return c.Command(
+		result,
+		"XGROUP",
+		cmd...,
+	)
+}
+
+// XRead read data from one or multiple streams, only returning entries with an ID greater than the last received ID reported by the caller.
+// The streams and their entries are scanned into a *[]XStream result.
+func (c *Commander) XRead(result interface{}, streamList, idList []string, options ...XReadOption) *Commander {
+	cmd := redis.Args{}
+	for _, option := range options {
+		cmd = cmd.Add(option.xreadOption()...)
+	}
+	cmd = cmd.Add("STREAMS")
+	for _, stream := range streamList {
+		cmd = cmd.Add(stream)
+	}
+	for _, id := range idList {
+		cmd = cmd.Add(id)
+	}
+	return c.Command(
+		result,
+		"XREAD",
+		cmd...,
+	)
+}
+
+// XReadGroup s a special version of the XREAD command with support for consumer groups.
+// The streams and their entries are scanned into a *[]XStream result.
+func (c *Commander) XReadGroup(result interface{}, groupName, consumerName string, streamList, idList []string, options ...XReadGroupOption) *Commander {
+	cmd := redis.Args{}
+	cmd = cmd.Add("GROUP").Add(groupName).Add(consumerName)
+	for _, option := range options {
+		cmd = cmd.Add(option.xreadGroupOption()...)
+	}
+	cmd = cmd.Add("STREAMS")
+	for _, stream := range streamList {
+		cmd = cmd.Add(stream)
+	}
+	for _, id := range idList {
+		cmd = cmd.Add(id)
+	}
+	return c.Command(
+		result,
+		"XREADGROUP",
+		cmd...,
+	)
+}
+
+// XAck  removes one or multiple messages from the pending entries list (PEL) of a stream consumer group.
+func (c *Commander) XAck(result interface{}, streamName, groupName string, idList []string) *Commander {
+	cmd := redis.Args{}
+	cmd = cmd.Add(streamName)
+	cmd = cmd.Add(groupName)
+	for _, id := range idList {
+		cmd = cmd.Add(id)
+	}
+	return c.Command(
+		result,
+		"XACK",
+		cmd...,
+	)
+}
+
+// XPending fetching data from a stream via a consumer group, and not acknowledging such data, has the effect of creating pending entries.
+func (c *Commander) XPending(result interface{}, streamName, groupName string, options ...XPendingOption) *Commander {
+	cmd := redis.Args{}
+	cmd = cmd.Add(streamName)
+	cmd = cmd.Add(groupName)
+	for _, option := range options {
+		cmd = cmd.Add(option.xpendingOption()...)
+	}
+	return c.Command(
+		result,
+		"XPENDING",
+		cmd...,
+	)
+}
+
+// XClaim this command changes the ownership of a pending message, so that the new owner is the consumer specified as the command argument.
+// The claimed entries are scanned into a *[]XMessage result.
 func (c *Commander) XClaim(result interface{}, streamName, groupName, consumerName string, minIdleTime uint64, idList []string, options ...XClaimOption) *Commander {
 	cmd := redis.Args{}
 	cmd = cmd.Add(streamName)
@@ -502,66 +1911,890 @@ func (c *Commander) XClaim(result interface{}, streamName, groupName, consumerNa
 		cmd = cmd.Add(id)
 	}
 	for _, option := range options {
-		cmd = cmd.Add(option.xclaimOption()...)
+		cmd = cmd.Add(option.xclaimOption()...)
+	}
+	return c.Command(
+		result,
+		"XCLAIM",
+		cmd...,
+	)
+}
+
+// Exists if key exists
+func (c *Commander) Exists(result *int, keys ...string) *Commander {
+	iKeys := make([]interface{}, len(keys))
+	for i := range keys {
+		iKeys[i] = keys[i]
+	}
+	return c.Command(result, "EXISTS", iKeys...)
+}
+
+// HSet sets field in the hash stored at key to value. If key does not exist, a new key holding a hash is created.
+func (c *Commander) HSet(result *int, key string, fields []string, value []interface{}) *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	if len(fields) != len(value) {
+		c.err = errors.New("bluto: the number of fields and values of HSET are not equal")
+		return c
+	}
+	cmd := redis.Args{}
+	cmd = cmd.Add(key)
+	for index := range fields {
+		cmd = cmd.Add(fields[index])
+		cmd = cmd.Add(value[index])
+	}
+	return c.Command(result, "HSET", cmd...)
+}
+
+// HGet returns the value associated with field in the hash stored at key.
+func (c *Commander) HGet(result interface{}, key, field string) *Commander {
+	return c.Command(result, "HGET", key, field)
+}
+
+// HDel removes the specified fields from the hash stored at key.
+func (c *Commander) HDel(result *int, key string, fields []string) *Commander {
+	cmd := redis.Args{}
+	cmd = cmd.Add(key)
+	for _, field := range fields {
+		cmd = cmd.Add(field)
+	}
+	return c.Command(result, "HDEL", cmd...)
+}
+
+// HGetAll returns all fields and values of the hash stored at key, as alternating fields and values
+// in a *[]string result or as a *map[string]string result.
+func (c *Commander) HGetAll(result interface{}, key string) *Commander {
+	return c.Command(stringMapResult(result), "HGETALL", key)
+}
+
+// HSetNX sets field in the hash stored at key to value, only if field does not yet exist.
+func (c *Commander) HSetNX(result *bool, key string, field string, value interface{}) *Commander {
+	return c.Command(result, "HSETNX", key, field, value)
+}
+
+// HIncrBy increments the number stored at field in the hash stored at key by increment.
+func (c *Commander) HIncrBy(result *int64, key string, field string, increment int64) *Commander {
+	return c.Command(result, "HINCRBY", key, field, increment)
+}
+
+// HExists returns if field is an existing field in the hash stored at key.
+func (c *Commander) HExists(result *bool, key, field string) *Commander {
+	return c.Command(result, "HEXISTS", key, field)
+}
+
+// LPush inserts all the specified values at the head of the list stored at key and returns the length of the list.
+func (c *Commander) LPush(result *int, key string, values ...interface{}) *Commander {
+	return c.Command(result, "LPUSH", redis.Args{}.Add(key).Add(values...)...)
+}
+
+// RPush inserts all the specified values at the tail of the list stored at key and returns the length of the list.
+func (c *Commander) RPush(result *int, key string, values ...interface{}) *Commander {
+	return c.Command(result, "RPUSH", redis.Args{}.Add(key).Add(values...)...)
+}
+
+// LPushX inserts the values at the head of the list stored at key, only if key already exists and holds a list.
+func (c *Commander) LPushX(result *int, key string, values ...interface{}) *Commander {
+	return c.Command(result, "LPUSHX", redis.Args{}.Add(key).Add(values...)...)
+}
+
+// RPushX inserts the values at the tail of the list stored at key, only if key already exists and holds a list.
+func (c *Commander) RPushX(result *int, key string, values ...interface{}) *Commander {
+	return c.Command(result, "RPUSHX", redis.Args{}.Add(key).Add(values...)...)
+}
+
+// LPop removes and returns the first element of the list stored at key, or the first count elements with LPopOptionCount.
+func (c *Commander) LPop(result interface{}, key string, options ...LPopOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.lpopOption()...)
+	}
+	return c.Command(result, "LPOP", cmd...)
+}
+
+// RPop removes and returns the last element of the list stored at key, or the last count elements with RPopOptionCount.
+func (c *Commander) RPop(result interface{}, key string, options ...RPopOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.rpopOption()...)
+	}
+	return c.Command(result, "RPOP", cmd...)
+}
+
+// LRange returns the elements of the list stored at key between the zero-based offsets start and stop, inclusive.
+func (c *Commander) LRange(result interface{}, key string, start, stop int) *Commander {
+	return c.Command(result, "LRANGE", key, start, stop)
+}
+
+// LLen returns the length of the list stored at key. If key does not exist, it is interpreted as an empty list and 0 is returned.
+func (c *Commander) LLen(result *int, key string) *Commander {
+	return c.Command(result, "LLEN", key)
+}
+
+// LIndex returns the element at index in the list stored at key. If index is out of range the special value nil is returned.
+func (c *Commander) LIndex(result interface{}, key string, index int) *Commander {
+	return c.Command(result, "LINDEX", key, index)
+}
+
+// LInsert inserts element in the list stored at key either before or after the pivot and returns the length of the list, or -1 if the pivot is not found.
+func (c *Commander) LInsert(result *int, key string, position ListPosition, pivot, element interface{}) *Commander {
+	return c.Command(result, "LINSERT", key, string(position), pivot, element)
+}
+
+// LSet sets the list element at index to element.
+func (c *Commander) LSet(result *string, key string, index int, element interface{}) *Commander {
+	return c.Command(result, "LSET", key, index, element)
+}
+
+// LRem removes the first count occurrences of element from the list stored at key, a negative count removes from the tail and 0 removes all of them.
+func (c *Commander) LRem(result *int, key string, count int, element interface{}) *Commander {
+	return c.Command(result, "LREM", key, count, element)
+}
+
+// LTrim trims the list stored at key so that it will contain only the elements between start and stop, inclusive.
+func (c *Commander) LTrim(result *string, key string, start, stop int) *Commander {
+	return c.Command(result, "LTRIM", key, start, stop)
+}
+
+// LPos (Redis>=6.0.6) returns the index of the matching element, or the indexes of the matching elements with LPosOptionCount.
+func (c *Commander) LPos(result interface{}, key string, element interface{}, options ...LPosOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(element)
+	for _, option := range options {
+		cmd = cmd.Add(option.lposOption()...)
+	}
+	return c.Command(result, "LPOS", cmd...)
+}
+
+// LMove (Redis>=6.2) atomically removes the element at the whereFrom side of the source list, pushes it at the whereTo side of the destination list and returns it.
+func (c *Commander) LMove(result interface{}, source, destination string, whereFrom, whereTo ListDirection) *Commander {
+	return c.Command(result, "LMOVE", source, destination, string(whereFrom), string(whereTo))
+}
+
+// BLPop is the blocking version of LPop, it pops from the first non-empty list of the keys or blocks until timeout, zero timeout blocks forever.
+// The read deadline of the connection is extended by the timeout and result.Timeout is set if no element is popped.
+func (c *Commander) BLPop(result *ListElement, timeout time.Duration, keys ...string) *Commander {
+	c.block(timeout)
+	return c.Command(result, "BLPOP", redis.Args{}.AddFlat(keys).Add(timeout.Seconds())...)
+}
+
+// BRPop is the blocking version of RPop, it pops from the first non-empty list of the keys or blocks until timeout, zero timeout blocks forever.
+// The read deadline of the connection is extended by the timeout and result.Timeout is set if no element is popped.
+func (c *Commander) BRPop(result *ListElement, timeout time.Duration, keys ...string) *Commander {
+	c.block(timeout)
+	return c.Command(result, "BRPOP", redis.Args{}.AddFlat(keys).Add(timeout.Seconds())...)
+}
+
+// BLMove (Redis>=6.2) is the blocking version of LMove, it blocks until timeout if the source list is empty, zero timeout blocks forever.
+// The read deadline of the connection is extended by the timeout and result.Timeout is set if no element is moved.
+func (c *Commander) BLMove(result *ListElement, source, destination string, whereFrom, whereTo ListDirection, timeout time.Duration) *Commander {
+	c.block(timeout)
+	return c.Command(result, "BLMOVE", source, destination, string(whereFrom), string(whereTo), timeout.Seconds())
+}
+
+// BLMPop (Redis>=7.0) pops elements from the where side of the first non-empty list of the keys or blocks until timeout, zero timeout blocks forever.
+// The read deadline of the connection is extended by the timeout and result.Timeout is set if no element is popped.
+func (c *Commander) BLMPop(result *ListElements, timeout time.Duration, where ListDirection, keys []string, options ...BLMPopOption) *Commander {
+	c.block(timeout)
+	cmd := redis.Args{}.Add(timeout.Seconds()).Add(len(keys)).AddFlat(keys).Add(string(where))
+	for _, option := range options {
+		cmd = cmd.Add(option.blmpopOption()...)
+	}
+	return c.Command(result, "BLMPOP", cmd...)
+}
+
+// BZPopMin pops the member with the lowest score from the first non-empty sorted set of the keys or blocks until timeout, zero timeout blocks forever.
+// The read deadline of the connection is extended by the timeout and result.Timeout is set if no member is popped.
+func (c *Commander) BZPopMin(result *ZElement, timeout time.Duration, keys ...string) *Commander {
+	c.block(timeout)
+	return c.Command(result, "BZPOPMIN", redis.Args{}.AddFlat(keys).Add(timeout.Seconds())...)
+}
+
+// BZPopMax pops the member with the highest score from the first non-empty sorted set of the keys or blocks until timeout, zero timeout blocks forever.
+// The read deadline of the connection is extended by the timeout and result.Timeout is set if no member is popped.
+func (c *Commander) BZPopMax(result *ZElement, timeout time.Duration, keys ...string) *Commander {
+	c.block(timeout)
+	return c.Command(result, "BZPOPMAX", redis.Args{}.AddFlat(keys).Add(timeout.Seconds())...)
+}
+
+// SAdd adds the specified members to the set stored at key and returns the number of the added members.
+func (c *Commander) SAdd(result *int, key string, members ...interface{}) *Commander {
+	return c.Command(result, "SADD", redis.Args{}.Add(key).Add(members...)...)
+}
+
+// SRem removes the specified members from the set stored at key and returns the number of the removed members.
+func (c *Commander) SRem(result *int, key string, members ...interface{}) *Commander {
+	return c.Command(result, "SREM", redis.Args{}.Add(key).Add(members...)...)
+}
+
+// SMembers returns all the members of the set stored at key.
+func (c *Commander) SMembers(result *[]string, key string) *Commander {
+	return c.Command(result, "SMEMBERS", key)
+}
+
+// SIsMember returns if member is a member of the set stored at key.
+func (c *Commander) SIsMember(result *bool, key string, member interface{}) *Commander {
+	return c.Command(result, "SISMEMBER", key, member)
+}
+
+// SMIsMember (Redis>=6.2) returns whether each member is a member of the set stored at key.
+func (c *Commander) SMIsMember(result *[]bool, key string, members ...interface{}) *Commander {
+	return c.Command(result, "SMISMEMBER", redis.Args{}.Add(key).Add(members...)...)
+}
+
+// SCard returns the number of members of the set stored at key.
+func (c *Commander) SCard(result *int, key string) *Commander {
+	return c.Command(result, "SCARD", key)
+}
+
+// SInter returns the members of the set resulting from the intersection of all the given sets.
+func (c *Commander) SInter(result *[]string, keys ...string) *Commander {
+	return c.Command(result, "SINTER", redis.Args{}.AddFlat(keys)...)
+}
+
+// SUnion returns the members of the set resulting from the union of all the given sets.
+func (c *Commander) SUnion(result *[]string, keys ...string) *Commander {
+	return c.Command(result, "SUNION", redis.Args{}.AddFlat(keys)...)
+}
+
+// SDiff returns the members of the set resulting from the difference between the first set and all the successive sets.
+func (c *Commander) SDiff(result *[]string, keys ...string) *Commander {
+	return c.Command(result, "SDIFF", redis.Args{}.AddFlat(keys)...)
+}
+
+// SInterStore stores the intersection of all the given sets in destination and returns its number of members.
+func (c *Commander) SInterStore(result *int, destination string, keys ...string) *Commander {
+	return c.Command(result, "SINTERSTORE", redis.Args{}.Add(destination).AddFlat(keys)...)
+}
+
+// SUnionStore stores the union of all the given sets in destination and returns its number of members.
+func (c *Commander) SUnionStore(result *int, destination string, keys ...string) *Commander {
+	return c.Command(result, "SUNIONSTORE", redis.Args{}.Add(destination).AddFlat(keys)...)
+}
+
+// SDiffStore stores the difference between the first set and all the successive sets in destination and returns its number of members.
+func (c *Commander) SDiffStore(result *int, destination string, keys ...string) *Commander {
+	return c.Command(result, "SDIFFSTORE", redis.Args{}.Add(destination).AddFlat(keys)...)
+}
+
+// SInterCard (Redis>=7.0) returns the number of members of the set resulting from the intersection of all the given sets.
+func (c *Commander) SInterCard(result *int, keys []string, options ...SInterCardOption) *Commander {
+	cmd := redis.Args{}.Add(len(keys)).AddFlat(keys)
+	for _, option := range options {
+		cmd = cmd.Add(option.sinterCardOption()...)
+	}
+	return c.Command(result, "SINTERCARD", cmd...)
+}
+
+// SPop removes and returns a random member of the set stored at key, or up to count members with SPopOptionCount.
+func (c *Commander) SPop(result interface{}, key string, options ...SPopOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.spopOption()...)
+	}
+	return c.Command(result, "SPOP", cmd...)
+}
+
+// SRandMember returns a random member of the set stored at key, or count members with SRandMemberOptionCount.
+func (c *Commander) SRandMember(result interface{}, key string, options ...SRandMemberOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.srandMemberOption()...)
+	}
+	return c.Command(result, "SRANDMEMBER", cmd...)
+}
+
+// SMove moves member from the set at source to the set at destination and returns if it is moved.
+func (c *Commander) SMove(result *bool, source, destination string, member interface{}) *Commander {
+	return c.Command(result, "SMOVE", source, destination, member)
+}
+
+// ZAdd adds the members with their scores to the sorted set stored at key, or updates the scores of the existing members.
+// It returns the number of the added members, or the new score of the member with ZAddOptionINCR.
+func (c *Commander) ZAdd(result interface{}, key string, scores []float64, members []interface{}, options ...ZAddOption) *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	if len(scores) != len(members) {
+		c.err = errors.New("bluto: the number of scores and members of ZADD are not equal")
+		return c
+	}
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.zaddOption()...)
+	}
+	for index := range scores {
+		cmd = cmd.Add(scores[index]).Add(members[index])
+	}
+	return c.Command(result, "ZADD", cmd...)
+}
+
+// ZRange returns the members of the sorted set stored at key between start and stop, which are ranks by default or scores and members with ZRangeOptionByScore and ZRangeOptionByLex.
+// The members and their scores are scanned into a *[]ZMember result with ZRangeOptionWithScores.
+func (c *Commander) ZRange(result interface{}, key string, start, stop interface{}, options ...ZRangeOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(start).Add(stop)
+	for _, option := range options {
+		cmd = cmd.Add(option.zrangeOption()...)
 	}
-	return c.Command(
-		result,
-		"XCLAIM",
-		cmd...,
-	)
+	return c.Command(zmembersResult(result), "ZRANGE", cmd...)
 }
 
-// Exists if key exists
-func (c *Commander) Exists(result *int, keys ...string) *Commander {
-	iKeys := make([]interface{}, len(keys))
-	for i := range keys {
-		iKeys[i] = keys[i]
+// ZRangeStore (Redis>=6.2) stores the range of the sorted set stored at key like ZRange in destination and returns its number of members.
+func (c *Commander) ZRangeStore(result *int, destination, key string, start, stop interface{}, options ...ZRangeStoreOption) *Commander {
+	cmd := redis.Args{}.Add(destination).Add(key).Add(start).Add(stop)
+	for _, option := range options {
+		cmd = cmd.Add(option.zrangeStoreOption()...)
 	}
-	return c.Command(result, "EXISTS", iKeys...)
+	return c.Command(result, "ZRANGESTORE", cmd...)
 }
 
-// HSet sets field in the hash stored at key to value. If key does not exist, a new key holding a hash is created.
-func (c *Commander) HSet(result *int, key string, fields []string, value []interface{}) *Commander {
-	cmd := redis.Args{}
-	cmd = cmd.Add(key)
-	for index := range fields {
-		cmd = cmd.Add(fields[index])
-		cmd = cmd.Add(value[index])
+// ZRank returns the rank of member in the sorted set stored at key ordered from low to high scores. If member does not exist the special value nil is returned.
+func (c *Commander) ZRank(result interface{}, key string, member interface{}) *Commander {
+	return c.Command(result, "ZRANK", key, member)
+}
+
+// ZRevRank returns the rank of member in the sorted set stored at key ordered from high to low scores. If member does not exist the special value nil is returned.
+func (c *Commander) ZRevRank(result interface{}, key string, member interface{}) *Commander {
+	return c.Command(result, "ZREVRANK", key, member)
+}
+
+// ZScore returns the score of member in the sorted set stored at key. If member does not exist the special value nil is returned.
+func (c *Commander) ZScore(result interface{}, key string, member interface{}) *Commander {
+	return c.Command(result, "ZSCORE", key, member)
+}
+
+// ZMScore (Redis>=6.2) returns the scores of the members in the sorted set stored at key, nil for the members which do not exist.
+func (c *Commander) ZMScore(result interface{}, key string, members ...interface{}) *Commander {
+	return c.Command(result, "ZMSCORE", redis.Args{}.Add(key).Add(members...)...)
+}
+
+// ZIncrBy increments the score of member in the sorted set stored at key by increment and returns the new score.
+func (c *Commander) ZIncrBy(result *float64, key string, increment float64, member interface{}) *Commander {
+	return c.Command(result, "ZINCRBY", key, increment, member)
+}
+
+// ZRem removes the specified members from the sorted set stored at key and returns the number of the removed members.
+func (c *Commander) ZRem(result *int, key string, members ...interface{}) *Commander {
+	return c.Command(result, "ZREM", redis.Args{}.Add(key).Add(members...)...)
+}
+
+// ZRemRangeByScore removes all the members of the sorted set stored at key with a score between min and max, inclusive unless prefixed by "(".
+func (c *Commander) ZRemRangeByScore(result *int, key string, min, max interface{}) *Commander {
+	return c.Command(result, "ZREMRANGEBYSCORE", key, min, max)
+}
+
+// ZRemRangeByRank removes all the members of the sorted set stored at key with a rank between start and stop, inclusive.
+func (c *Commander) ZRemRangeByRank(result *int, key string, start, stop int) *Commander {
+	return c.Command(result, "ZREMRANGEBYRANK", key, start, stop)
+}
+
+// ZRemRangeByLex removes all the members of the sorted set stored at key between the lexicographical range min and max.
+func (c *Commander) ZRemRangeByLex(result *int, key string, min, max string) *Commander {
+	return c.Command(result, "ZREMRANGEBYLEX", key, min, max)
+}
+
+// ZCard returns the number of members of the sorted set stored at key.
+func (c *Commander) ZCard(result *int, key string) *Commander {
+	return c.Command(result, "ZCARD", key)
+}
+
+// ZCount returns the number of members of the sorted set stored at key with a score between min and max, inclusive unless prefixed by "(".
+func (c *Commander) ZCount(result *int, key string, min, max interface{}) *Commander {
+	return c.Command(result, "ZCOUNT", key, min, max)
+}
+
+// ZPopMin removes and returns the member with the lowest score and its score, or up to count members with ZPopMinOptionCount.
+// The members and their scores can be scanned into a *[]ZMember result.
+func (c *Commander) ZPopMin(result interface{}, key string, options ...ZPopMinOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.zpopMinOption()...)
 	}
-	return c.Command(result, "HSET", cmd...)
+	return c.Command(zmembersResult(result), "ZPOPMIN", cmd...)
 }
 
-// HGet returns the value associated with field in the hash stored at key.
-func (c *Commander) HGet(result interface{}, key, field string) *Commander {
-	return c.Command(result, "HGET", key, field)
+// ZPopMax removes and returns the member with the highest score and its score, or up to count members with ZPopMaxOptionCount.
+// The members and their scores can be scanned into a *[]ZMember result.
+func (c *Commander) ZPopMax(result interface{}, key string, options ...ZPopMaxOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.zpopMaxOption()...)
+	}
+	return c.Command(zmembersResult(result), "ZPOPMAX", cmd...)
 }
 
-// HDel removes the specified fields from the hash stored at key.
-func (c *Commander) HDel(result *int, key string, fields []string) *Commander {
+// ZUnion (Redis>=6.2) returns the union of the sorted sets of the keys, the scores of each member are aggregated.
+// The members and their scores are scanned into a *[]ZMember result with the WITHSCORES option.
+func (c *Commander) ZUnion(result interface{}, keys []string, options ...ZCombineOption) *Commander {
+	cmd := redis.Args{}.Add(len(keys)).AddFlat(keys)
+	for _, option := range options {
+		cmd = cmd.Add(option.zcombineOption()...)
+	}
+	return c.Command(zmembersResult(result), "ZUNION", cmd...)
+}
+
+// ZInter (Redis>=6.2) returns the intersection of the sorted sets of the keys, the scores of each member are aggregated.
+// The members and their scores are scanned into a *[]ZMember result with the WITHSCORES option.
+func (c *Commander) ZInter(result interface{}, keys []string, options ...ZCombineOption) *Commander {
+	cmd := redis.Args{}.Add(len(keys)).AddFlat(keys)
+	for _, option := range options {
+		cmd = cmd.Add(option.zcombineOption()...)
+	}
+	return c.Command(zmembersResult(result), "ZINTER", cmd...)
+}
+
+// ZDiff (Redis>=6.2) returns the difference between the first sorted set and all the successive sorted sets of the keys.
+// The members and their scores are scanned into a *[]ZMember result with the WITHSCORES option.
+func (c *Commander) ZDiff(result interface{}, keys []string, options ...ZDiffOption) *Commander {
+	cmd := redis.Args{}.Add(len(keys)).AddFlat(keys)
+	for _, option := range options {
+		cmd = cmd.Add(option.zdiffOption()...)
+	}
+	return c.Command(zmembersResult(result), "ZDIFF", cmd...)
+}
+
+// MGet returns the values of all the specified keys, the special value nil is returned for the keys which do not exist.
+// The missing keys can be reported by a *[]NullString result.
+func (c *Commander) MGet(result interface{}, keys ...string) *Commander {
+	return c.Command(result, "MGET", redis.Args{}.AddFlat(keys)...)
+}
+
+// MSet sets the keys to their respective values, replacing the existing values.
+func (c *Commander) MSet(result *string, keys []string, values []interface{}) *Commander {
+	return c.mset(result, "MSET", keys, values)
+}
+
+// MSetNX sets the keys to their respective values, only if none of the keys exists.
+func (c *Commander) MSetNX(result *bool, keys []string, values []interface{}) *Commander {
+	return c.mset(result, "MSETNX", keys, values)
+}
+
+// mset sends the key value pairs of MSET and MSETNX
+func (c *Commander) mset(result interface{}, name string, keys []string, values []interface{}) *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	if len(keys) != len(values) {
+		c.err = errors.New("bluto: the number of keys and values of " + name + " are not equal")
+		return c
+	}
 	cmd := redis.Args{}
-	cmd = cmd.Add(key)
-	for _, field := range fields {
-		cmd = cmd.Add(field)
+	for index := range keys {
+		cmd = cmd.Add(keys[index]).Add(values[index])
 	}
-	return c.Command(result, "HDEL", cmd...)
+	return c.Command(result, name, cmd...)
 }
 
-// HGetAll returns all fields and values of the hash stored at key.
-func (c *Commander) HGetAll(result *[]string, key string) *Commander {
-	return c.Command(result, "HGETALL", key)
+// Append appends the value at the end of the string stored at key and returns the length of the string.
+func (c *Commander) Append(result *int, key string, value interface{}) *Commander {
+	return c.Command(result, "APPEND", key, value)
 }
 
-// HSetNX sets field in the hash stored at key to value, only if field does not yet exist.
-func (c *Commander) HSetNX(result *bool, key string, field string, value interface{}) *Commander {
-	return c.Command(result, "HSETNX", key, field, value)
+// StrLen returns the length of the string value stored at key.
+func (c *Commander) StrLen(result *int, key string) *Commander {
+	return c.Command(result, "STRLEN", key)
 }
 
-// HIncrBy increments the number stored at field in the hash stored at key by increment.
-func (c *Commander) HIncrBy(result *int64, key string, field string, increment int64) *Commander {
-	return c.Command(result, "HINCRBY", key, field, increment)
+// GetRange returns the substring of the string value stored at key between the offsets start and end, inclusive.
+func (c *Commander) GetRange(result *string, key string, start, end int) *Commander {
+	return c.Command(result, "GETRANGE", key, start, end)
 }
 
-// HExists returns if field is an existing field in the hash stored at key.
-func (c *Commander) HExists(result *bool, key, field string) *Commander {
-	return c.Command(result, "HEXISTS", key, field)
+// SetRange overwrites part of the string stored at key starting at offset with value and returns the length of the string.
+func (c *Commander) SetRange(result *int, key string, offset int, value interface{}) *Commander {
+	return c.Command(result, "SETRANGE", key, offset, value)
+}
+
+// IncrBy increments the number stored at key by increment. If the key does not exist, it is set to 0.
+func (c *Commander) IncrBy(result *int64, key string, increment int64) *Commander {
+	return c.Command(result, "INCRBY", key, increment)
+}
+
+// DecrBy decrements the number stored at key by decrement. If the key does not exist, it is set to 0.
+func (c *Commander) DecrBy(result *int64, key string, decrement int64) *Commander {
+	return c.Command(result, "DECRBY", key, decrement)
+}
+
+// IncrByFloat increments the floating point number stored at key by increment. If the key does not exist, it is set to 0.
+func (c *Commander) IncrByFloat(result *float64, key string, increment float64) *Commander {
+	return c.Command(result, "INCRBYFLOAT", key, increment)
+}
+
+// GetSet atomically sets key to value and returns the old value stored at key. If the key does not exist the special value nil is returned.
+func (c *Commander) GetSet(result interface{}, key string, value interface{}) *Commander {
+	return c.Command(result, "GETSET", key, value)
+}
+
+// GetDel (Redis>=6.2) returns the value of key and deletes the key. If the key does not exist the special value nil is returned.
+func (c *Commander) GetDel(result interface{}, key string) *Commander {
+	return c.Command(result, "GETDEL", key)
+}
+
+// GetEx (Redis>=6.2) returns the value of key and optionally sets or removes its expiration. If the key does not exist the special value nil is returned.
+func (c *Commander) GetEx(result interface{}, key string, options ...GetExOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.getExOption()...)
+	}
+	return c.Command(result, "GETEX", cmd...)
+}
+
+// SetBit sets or clears the bit at offset in the string value stored at key and returns the original bit value.
+func (c *Commander) SetBit(result *int, key string, offset uint64, value int) *Commander {
+	return c.Command(result, "SETBIT", key, offset, value)
+}
+
+// GetBit returns the bit value at offset in the string value stored at key.
+func (c *Commander) GetBit(result *int, key string, offset uint64) *Commander {
+	return c.Command(result, "GETBIT", key, offset)
+}
+
+// BitCount returns the number of set bits in the string value stored at key, or in a range of it with BitCountOptionRange.
+func (c *Commander) BitCount(result *int, key string, options ...BitCountOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.bitCountOption()...)
+	}
+	return c.Command(result, "BITCOUNT", cmd...)
+}
+
+// BitPos returns the position of the first bit set to 1 or 0 in the string value stored at key, or -1 if it is not found.
+func (c *Commander) BitPos(result *int, key string, bit int, options ...BitPosOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(bit)
+	for _, option := range options {
+		cmd = cmd.Add(option.bitPosOption()...)
+	}
+	return c.Command(result, "BITPOS", cmd...)
+}
+
+// BitOp performs the bitwise operation between the keys, stores the result in destination and returns its length.
+func (c *Commander) BitOp(result *int, operation BitOperation, destination string, keys ...string) *Commander {
+	return c.Command(result, "BITOP", redis.Args{}.Add(string(operation)).Add(destination).AddFlat(keys)...)
+}
+
+// BitField runs the operations of the bitField on the string value stored at key and returns their results in order,
+// the result of an operation which fails because of OVERFLOW FAIL is nil.
+func (c *Commander) BitField(result *[]*int64, key string, bitField *BitField) *Commander {
+	return c.Command((*bitFieldResults)(result), "BITFIELD", redis.Args{}.Add(key).Add(bitField.args...)...)
+}
+
+// PFAdd adds the elements to the HyperLogLog stored at key and returns if its approximated cardinality is changed.
+func (c *Commander) PFAdd(result *bool, key string, elements ...string) *Commander {
+	return c.Command(result, "PFADD", redis.Args{}.Add(key).AddFlat(elements)...)
+}
+
+// PFCount returns the approximated cardinality of the union of the HyperLogLogs stored at the keys.
+func (c *Commander) PFCount(result *int, keys ...string) *Commander {
+	return c.Command(result, "PFCOUNT", redis.Args{}.AddFlat(keys)...)
+}
+
+// PFMerge merges the HyperLogLogs stored at the source keys into the HyperLogLog stored at destination.
+func (c *Commander) PFMerge(result *string, destination string, sourceKeys ...string) *Commander {
+	return c.Command(result, "PFMERGE", redis.Args{}.Add(destination).AddFlat(sourceKeys)...)
+}
+
+// GeoAdd adds the locations with their Name, Longitude and Latitude to the geospatial index stored at key and returns the number of the added members.
+func (c *Commander) GeoAdd(result *int, key string, locations []GeoLocation, options ...GeoAddOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.geoAddOption()...)
+	}
+	for _, location := range locations {
+		cmd = cmd.Add(location.Longitude, location.Latitude, location.Name)
+	}
+	return c.Command(result, "GEOADD", cmd...)
+}
+
+// GeoDist returns the distance between the two members in meters, or in the unit of GeoDistOptionUnit. If one of the members does not exist the special value nil is returned.
+func (c *Commander) GeoDist(result interface{}, key, member1, member2 string, options ...GeoDistOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(member1).Add(member2)
+	for _, option := range options {
+		cmd = cmd.Add(option.geoDistOption()...)
+	}
+	return c.Command(result, "GEODIST", cmd...)
+}
+
+// GeoPos returns the locations of the members with their Name, Longitude and Latitude, the location of a member which does not exist is nil.
+func (c *Commander) GeoPos(result *[]*GeoLocation, key string, members ...string) *Commander {
+	return c.Command(&geoPositions{result: result, members: members}, "GEOPOS", redis.Args{}.Add(key).AddFlat(members)...)
+}
+
+// GeoHash returns the geohash strings of the members, the special value nil is returned for the members which do not exist.
+// The missing members can be reported by a *[]NullString result.
+func (c *Commander) GeoHash(result interface{}, key string, members ...string) *Commander {
+	return c.Command(result, "GEOHASH", redis.Args{}.Add(key).AddFlat(members)...)
+}
+
+// GeoSearch (Redis>=6.2) returns the members of the geospatial index stored at key which are in the area of the shape around the center.
+// The members are scanned into a *[]GeoLocation result with the coordinates, distances and geohashes of the WITH options.
+func (c *Commander) GeoSearch(result interface{}, key string, from GeoFrom, by GeoBy, options ...GeoSearchOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(from.geoFrom()...).Add(by.geoBy()...)
+	for _, option := range options {
+		cmd = cmd.Add(option.geoSearchOption()...)
+	}
+	return c.Command(result, "GEOSEARCH", cmd...)
+}
+
+// GeoSearchStore (Redis>=6.2) stores the members of GeoSearch in destination and returns their number.
+func (c *Commander) GeoSearchStore(result *int, destination, source string, from GeoFrom, by GeoBy, options ...GeoSearchStoreOption) *Commander {
+	cmd := redis.Args{}.Add(destination).Add(source).Add(from.geoFrom()...).Add(by.geoBy()...)
+	for _, option := range options {
+		cmd = cmd.Add(option.geoSearchStoreOption()...)
+	}
+	return c.Command(result, "GEOSEARCHSTORE", cmd...)
+}
+
+// PExpire set a timeout on key in milliseconds. After the timeout has expired, the key will automatically be deleted.
+func (c *Commander) PExpire(result *bool, key string, milliseconds int64, options ...ExpireOption) *Commander {
+	return c.expire(result, "PEXPIRE", key, milliseconds, options)
+}
+
+// ExpireAt set the Unix time in seconds at which key will automatically be deleted.
+func (c *Commander) ExpireAt(result *bool, key string, timestamp int64, options ...ExpireOption) *Commander {
+	return c.expire(result, "EXPIREAT", key, timestamp, options)
+}
+
+// PExpireAt set the Unix time in milliseconds at which key will automatically be deleted.
+func (c *Commander) PExpireAt(result *bool, key string, timestamp int64, options ...ExpireOption) *Commander {
+	return c.expire(result, "PEXPIREAT", key, timestamp, options)
+}
+
+// expire sends the expire commands with their options
+func (c *Commander) expire(result *bool, name string, key string, expiry interface{}, options []ExpireOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(expiry)
+	for _, option := range options {
+		cmd = cmd.Add(option.expireOption()...)
+	}
+	return c.Command(result, name, cmd...)
+}
+
+// TTL returns the remaining time to live of key in seconds, it is TTLNoExpiry if the key has no expiry and TTLMissingKey if the key does not exist.
+func (c *Commander) TTL(result *time.Duration, key string) *Commander {
+	return c.Command(&ttlResult{result: result, unit: time.Second}, "TTL", key)
+}
+
+// PTTL returns the remaining time to live of key in milliseconds, it is TTLNoExpiry if the key has no expiry and TTLMissingKey if the key does not exist.
+func (c *Commander) PTTL(result *time.Duration, key string) *Commander {
+	return c.Command(&ttlResult{result: result, unit: time.Millisecond}, "PTTL", key)
+}
+
+// ExpireTime (Redis>=7.0) returns the Unix time in seconds at which key will expire, -1 if the key has no expiry and -2 if the key does not exist.
+func (c *Commander) ExpireTime(result *int64, key string) *Commander {
+	return c.Command(result, "EXPIRETIME", key)
+}
+
+// Persist removes the existing timeout on key and returns if the timeout is removed.
+func (c *Commander) Persist(result *bool, key string) *Commander {
+	return c.Command(result, "PERSIST", key)
+}
+
+// Rename renames key to newKey, newKey is overwritten if it already exists.
+func (c *Commander) Rename(result *string, key, newKey string) *Commander {
+	return c.Command(result, "RENAME", key, newKey)
+}
+
+// RenameNX renames key to newKey only if newKey does not exist and returns if the key is renamed.
+func (c *Commander) RenameNX(result *bool, key, newKey string) *Commander {
+	return c.Command(result, "RENAMENX", key, newKey)
+}
+
+// Type returns the type of the value stored at key, or none if the key does not exist.
+func (c *Commander) Type(result *string, key string) *Commander {
+	return c.Command(result, "TYPE", key)
+}
+
+// Unlink removes the specified keys like Del, but the memory is reclaimed in a different thread.
+func (c *Commander) Unlink(result *int, keys ...string) *Commander {
+	return c.Command(result, "UNLINK", redis.Args{}.AddFlat(keys)...)
+}
+
+// Touch alters the last access time of the keys and returns the number of the existing keys.
+func (c *Commander) Touch(result *int, keys ...string) *Commander {
+	return c.Command(result, "TOUCH", redis.Args{}.AddFlat(keys)...)
+}
+
+// Copy (Redis>=6.2) copies the value stored at source to destination and returns if it is copied.
+func (c *Commander) Copy(result *bool, source, destination string, options ...CopyOption) *Commander {
+	cmd := redis.Args{}.Add(source).Add(destination)
+	for _, option := range options {
+		cmd = cmd.Add(option.copyOption()...)
+	}
+	return c.Command(result, "COPY", cmd...)
+}
+
+// ObjectEncoding returns the internal encoding of the value stored at key.
+func (c *Commander) ObjectEncoding(result *string, key string) *Commander {
+	return c.Command(result, "OBJECT", "ENCODING", key)
+}
+
+// ObjectFreq returns the logarithmic access frequency counter of key, it is only available with an LFU maxmemory policy.
+func (c *Commander) ObjectFreq(result *int, key string) *Commander {
+	return c.Command(result, "OBJECT", "FREQ", key)
+}
+
+// ObjectIdleTime returns the number of seconds since key was last accessed.
+func (c *Commander) ObjectIdleTime(result *int64, key string) *Commander {
+	return c.Command(result, "OBJECT", "IDLETIME", key)
+}
+
+// Dump returns the value stored at key serialized in a Redis-specific format. If the key does not exist the special value nil is returned.
+func (c *Commander) Dump(result *[]byte, key string) *Commander {
+	return c.Command(result, "DUMP", key)
+}
+
+// Restore creates key from the value serialized by Dump, ttl is the time to live of key in milliseconds and 0 means no expiry.
+func (c *Commander) Restore(result *string, key string, ttl int64, serializedValue []byte, options ...RestoreOption) *Commander {
+	cmd := redis.Args{}.Add(key).Add(ttl).Add(serializedValue)
+	for _, option := range options {
+		cmd = cmd.Add(option.restoreOption()...)
+	}
+	return c.Command(result, "RESTORE", cmd...)
+}
+
+// HMGet returns the values associated with the fields in the hash stored at key, the special value nil is returned for the fields which do not exist.
+// The missing fields can be reported by a *[]NullString result.
+func (c *Commander) HMGet(result interface{}, key string, fields ...string) *Commander {
+	return c.Command(result, "HMGET", redis.Args{}.Add(key).AddFlat(fields)...)
+}
+
+// HKeys returns all field names in the hash stored at key.
+func (c *Commander) HKeys(result *[]string, key string) *Commander {
+	return c.Command(result, "HKEYS", key)
+}
+
+// HVals returns all values in the hash stored at key.
+func (c *Commander) HVals(result *[]string, key string) *Commander {
+	return c.Command(result, "HVALS", key)
+}
+
+// HLen returns the number of fields contained in the hash stored at key.
+func (c *Commander) HLen(result *int, key string) *Commander {
+	return c.Command(result, "HLEN", key)
+}
+
+// HStrLen returns the string length of the value associated with field in the hash stored at key.
+func (c *Commander) HStrLen(result *int, key, field string) *Commander {
+	return c.Command(result, "HSTRLEN", key, field)
+}
+
+// HIncrByFloat increments the floating point number stored at field in the hash stored at key by increment.
+func (c *Commander) HIncrByFloat(result *float64, key, field string, increment float64) *Commander {
+	return c.Command(result, "HINCRBYFLOAT", key, field, increment)
+}
+
+// HRandField (Redis>=6.2) returns a random field of the hash stored at key, or count fields with HRandFieldOptionCount.
+// The fields and their values are scanned into a *map[string]string result with WithValues.
+func (c *Commander) HRandField(result interface{}, key string, options ...HRandFieldOption) *Commander {
+	cmd := redis.Args{}.Add(key)
+	for _, option := range options {
+		cmd = cmd.Add(option.hrandFieldOption()...)
+	}
+	return c.Command(stringMapResult(result), "HRANDFIELD", cmd...)
+}
+
+// HSetStruct sets the exported fields of the struct v in the hash stored at key, the fields are named by their redis tags.
+func (c *Commander) HSetStruct(result *int, key string, v interface{}, options ...HSetStructOption) *Commander {
+	// if there has been an error don't do anything
+	if c.err != nil {
+		return c
+	}
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		c.err = errors.New("bluto: the value of HSetStruct is not a struct")
+		return c
+	}
+	fields := redis.Args{}.AddFlat(value.Interface())
+	for _, option := range options {
+		fields = option.hsetStructOption(fields)
+	}
+	if len(fields) == 0 {
+		c.err = errors.New("bluto: the struct of HSetStruct has no fields to set")
+		return c
+	}
+	return c.Command(result, "HSET", redis.Args{}.Add(key).Add(fields...)...)
+}
+
+// HGetAllStruct scans all fields and values of the hash stored at key into the struct pointed by v, the fields are matched by their redis tags.
+func (c *Commander) HGetAllStruct(key string, v interface{}) *Commander {
+	return c.Command(&structResult{v: v}, "HGETALL", key)
+}
+
+// XRange returns the entries of the stream stored at streamName with IDs between start and end, - and + are the minimum and maximum IDs.
+// The entries are scanned into a *[]XMessage result.
+func (c *Commander) XRange(result interface{}, streamName, start, end string, options ...XRangeOption) *Commander {
+	cmd := redis.Args{}.Add(streamName).Add(start).Add(end)
+	for _, option := range options {
+		cmd = cmd.Add(option.xrangeOption()...)
+	}
+	return c.Command(result, "XRANGE", cmd...)
+}
+
+// XRevRange returns the entries of the stream stored at streamName with IDs between end and start in reverse order.
+// The entries are scanned into a *[]XMessage result.
+func (c *Commander) XRevRange(result interface{}, streamName, end, start string, options ...XRangeOption) *Commander {
+	cmd := redis.Args{}.Add(streamName).Add(end).Add(start)
+	for _, option := range options {
+		cmd = cmd.Add(option.xrangeOption()...)
+	}
+	return c.Command(result, "XREVRANGE", cmd...)
+}
+
+// XLen returns the number of entries of the stream stored at streamName.
+func (c *Commander) XLen(result *int, streamName string) *Commander {
+	return c.Command(result, "XLEN", streamName)
+}
+
+// XDel removes the entries with the specified IDs from the stream stored at streamName.
+func (c *Commander) XDel(result *int, streamName string, idList []string) *Commander {
+	return c.Command(result, "XDEL", redis.Args{}.Add(streamName).AddFlat(idList)...)
+}
+
+// XTrim evicts the older entries of the stream stored at streamName by XTrimOptionMaxLen or XTrimOptionMinID.
+func (c *Commander) XTrim(result *int, streamName string, options ...XTrimOption) *Commander {
+	cmd := redis.Args{}.Add(streamName)
+	for _, option := range options {
+		cmd = cmd.Add(option.xtrimOption()...)
+	}
+	return c.Command(result, "XTRIM", cmd...)
+}
+
+// XInfoStream returns the information about the stream stored at streamName.
+func (c *Commander) XInfoStream(result interface{}, streamName string, options ...XInfoStreamOption) *Commander {
+	cmd := redis.Args{}.Add("STREAM").Add(streamName)
+	for _, option := range options {
+		cmd = cmd.Add(option.xinfoStreamOption()...)
+	}
+	return c.Command(result, "XINFO", cmd...)
+}
+
+// XInfoGroups returns the consumer groups of the stream stored at streamName.
+func (c *Commander) XInfoGroups(result interface{}, streamName string) *Commander {
+	return c.Command(result, "XINFO", "GROUPS", streamName)
+}
+
+// XInfoConsumers returns the consumers of the consumer group of the stream stored at streamName.
+func (c *Commander) XInfoConsumers(result interface{}, streamName, groupName string) *Commander {
+	return c.Command(result, "XINFO", "CONSUMERS", streamName, groupName)
+}
+
+// XAutoClaim (Redis>=6.2) claims the pending messages which are idle for at least minIdleTime milliseconds like XClaim, starting from the start ID.
+// It returns the next start ID and the claimed messages, which are scanned into a *XAutoClaimResult result.
+func (c *Commander) XAutoClaim(result interface{}, streamName, groupName, consumerName string, minIdleTime uint64, start string, options ...XAutoClaimOption) *Commander {
+	cmd := redis.Args{}.Add(streamName).Add(groupName).Add(consumerName).Add(minIdleTime).Add(start)
+	for _, option := range options {
+		cmd = cmd.Add(option.xautoClaimOption()...)
+	}
+	return c.Command(result, "XAUTOCLAIM", cmd...)
+}
+
+// XGroupSetID sets the last delivered ID of the consumer group of the stream stored at streamName.
+func (c *Commander) XGroupSetID(result *string, streamName, groupName, streamID string) *Commander {
+	return c.Command(result, "XGROUP", "SETID", streamName, groupName, streamID)
+}
+
+// XGroupCreateConsumer (Redis>=6.2) creates the consumer in the consumer group of the stream stored at streamName.
+func (c *Commander) XGroupCreateConsumer(result *int, streamName, groupName, consumerName string) *Commander {
+	return c.Command(result, "XGROUP", "CREATECONSUMER", streamName, groupName, consumerName)
 }
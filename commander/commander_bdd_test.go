@@ -1616,6 +1616,1200 @@ var _ = Describe("Commander", func() {
 		})
 	})
 
+	Describe("Stream commands", func() {
+		It("should return the real results of valid range, length, delete and trim commands", func() {
+			key := "testStream"
+			var xaddResults [4]string
+			var xrangeResult []Message
+			var xrevrangeResult []Message
+			var xlenResult int
+			var xdelResult int
+			var xtrimResult int
+			var xtrimMinIDResult int
+			var xlenTrimmedResult int
+
+			errCmd := New(getConn()).
+				XAdd(&xaddResults[0], key, "1-0", Fields{Key: "a"}).
+				XAdd(&xaddResults[1], key, "2-0", Fields{Key: "b"}).
+				XAdd(&xaddResults[2], key, "3-0", Fields{Key: "c"}).
+				XAdd(&xaddResults[3], key, "4-0", Fields{Key: "d"}).
+				XRange(&xrangeResult, key, "-", "+", XRangeOptionCount{Count: 2}).
+				XRevRange(&xrevrangeResult, key, "+", "-", XRangeOptionCount{Count: 1}).
+				XLen(&xlenResult, key).
+				XDel(&xdelResult, key, []string{"1-0", "5-0"}).
+				XTrim(&xtrimResult, key, XTrimOptionMaxLen{MaxLen: 2}).
+				XTrim(&xtrimMinIDResult, key, XTrimOptionMinID{MinID: "4-0"}).
+				XLen(&xlenTrimmedResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(xaddResults).To(Equal([4]string{"1-0", "2-0", "3-0", "4-0"}))
+			Expect(xrangeResult).To(HaveLen(2))
+			Expect(xrangeResult[0].ID).To(Equal("1-0"))
+			Expect(xrangeResult[1].Fields.Key).To(Equal("b"))
+			Expect(xrevrangeResult).To(HaveLen(1))
+			Expect(xrevrangeResult[0].ID).To(Equal("4-0"))
+			Expect(xlenResult).To(Equal(4))
+			Expect(xdelResult).To(Equal(1))
+			Expect(xtrimResult).To(Equal(1))
+			Expect(xtrimMinIDResult).To(Equal(1))
+			Expect(xlenTrimmedResult).To(Equal(1))
+		})
+
+		It("should not create a stream with NOMKSTREAM and trim a stream by XADD options", func() {
+			key := "testStream"
+			var xaddMissingResult string
+			var xaddResults [3]string
+			var xlenResult int
+
+			errCmd := New(getConn()).
+				XAdd(&xaddMissingResult, key, "*", Fields{Key: "a"}, XAddOptionNoMkStream{}).
+				XAdd(&xaddResults[0], key, "1-0", Fields{Key: "a"}).
+				XAdd(&xaddResults[1], key, "2-0", Fields{Key: "b"}).
+				XAdd(&xaddResults[2], key, "3-0", Fields{Key: "c"}, XAddOptionMinID{MinID: "2-0"}).
+				XLen(&xlenResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(xaddMissingResult).To(Equal(""))
+			Expect(xaddResults).To(Equal([3]string{"1-0", "2-0", "3-0"}))
+			Expect(xlenResult).To(Equal(2))
+		})
+
+		It("should return the real results of valid consumer group commands", func() {
+			key := "testStream"
+			groupName := "testGroup"
+			var xaddResult string
+			var xgroupCreateResult string
+			var xgroupCreateConsumerResult int
+			var xreadgroupResult []Stream
+			var xautoclaimResult []interface{}
+			var xinfoStreamResult []interface{}
+			var xinfoGroupsResult []interface{}
+			var xinfoConsumersResult []interface{}
+
+			errCmd := New(getConn()).
+				XAdd(&xaddResult, key, "1-0", Fields{Key: "a"}).
+				XGroupCreate(&xgroupCreateResult, key, groupName, "0").
+				XGroupCreateConsumer(&xgroupCreateConsumerResult, key, groupName, "testConsumer1").
+				XReadGroup(&xreadgroupResult, groupName, "testConsumer1", []string{key}, []string{">"}).
+				XAutoClaim(&xautoclaimResult, key, groupName, "testConsumer2", 0, "0-0", XAutoClaimOptionCount{Count: 10}).
+				XInfoStream(&xinfoStreamResult, key).
+				XInfoGroups(&xinfoGroupsResult, key).
+				XInfoConsumers(&xinfoConsumersResult, key, groupName).
+				Commit()
+			Expect(errCmd).To(BeNil())
+			var nextID string
+			var xautoclaimMessages []Message
+			_, errScan := redis.Scan(xautoclaimResult, &nextID, &xautoclaimMessages)
+
+			Expect(errScan).To(BeNil())
+			Expect(xgroupCreateResult).To(Equal("OK"))
+			Expect(xgroupCreateConsumerResult).To(Equal(1))
+			Expect(xreadgroupResult[0].Messages[0].Fields.Key).To(Equal("a"))
+			Expect(nextID).To(Equal("0-0"))
+			Expect(xautoclaimMessages).To(HaveLen(1))
+			Expect(xautoclaimMessages[0].ID).To(Equal(xaddResult))
+			Expect(xinfoStreamResult).To(Not(BeEmpty()))
+			Expect(xinfoGroupsResult).To(HaveLen(1))
+			Expect(xinfoConsumersResult).To(HaveLen(2))
+		})
+	})
+
+	Describe("XStream and XMessage", func() {
+		It("should scan the entries of the stream commands", func() {
+			key := "testStream"
+			groupName := "testGroup"
+			var xgroupCreateResult string
+			var xaddResult1 string
+			var xaddResult2 string
+			var xreadResult []XStream
+			var xreadgroupResult []XStream
+			var xrangeResult []XMessage
+			var xclaimResult []XMessage
+			var xclaimJustIDResult []XMessage
+			var xautoclaimResult XAutoClaimResult
+
+			errCmd := New(getConn()).
+				XGroupCreate(&xgroupCreateResult, key, groupName, "0", XGroupCreateOptionMKStream{}).
+				XAdd(&xaddResult1, key, "1-0", Fields{Key: "a"}).
+				XAdd(&xaddResult2, key, "2-0", Fields{Key: "b"}).
+				XRead(&xreadResult, []string{key}, []string{"1-0"}).
+				XReadGroup(&xreadgroupResult, groupName, "testConsumer1", []string{key}, []string{">"}).
+				XRange(&xrangeResult, key, "-", "+").
+				XClaim(&xclaimResult, key, groupName, "testConsumer2", 0, []string{"1-0"}).
+				XClaim(&xclaimJustIDResult, key, groupName, "testConsumer2", 0, []string{"2-0"}, XClaimOptionJustID{}).
+				XAutoClaim(&xautoclaimResult, key, groupName, "testConsumer1", 0, "0-0").
+				Commit()
+			Expect(errCmd).To(BeNil())
+			var fields Fields
+			errScan := xrangeResult[1].ScanStruct(&fields)
+
+			Expect(errScan).To(BeNil())
+			Expect(xreadResult).To(Equal([]XStream{{
+				Name:     key,
+				Messages: []XMessage{{ID: "2-0", Values: map[string]string{"Key": "b"}}},
+			}}))
+			Expect(xreadgroupResult[0].Name).To(Equal(key))
+			Expect(xreadgroupResult[0].Messages).To(HaveLen(2))
+			Expect(xrangeResult).To(Equal([]XMessage{
+				{ID: "1-0", Values: map[string]string{"Key": "a"}},
+				{ID: "2-0", Values: map[string]string{"Key": "b"}},
+			}))
+			Expect(fields).To(Equal(Fields{Key: "b"}))
+			Expect(xclaimResult).To(Equal([]XMessage{{ID: "1-0", Values: map[string]string{"Key": "a"}}}))
+			Expect(xclaimJustIDResult).To(Equal([]XMessage{{ID: "2-0"}}))
+			Expect(xautoclaimResult.Next).To(Equal("0-0"))
+			Expect(xautoclaimResult.Messages).To(HaveLen(2))
+			Expect(xautoclaimResult.Messages[0].Values).To(Equal(map[string]string{"Key": "a"}))
+		})
+	})
+
+	Describe("Hashes", func() {
+		It("should return the real results of valid hash commands", func() {
+			key := "SomeKey"
+			var hSetResult int
+			var hGetAllResult map[string]string
+			var hMGetResult []NullString
+			var hKeysResult []string
+			var hValsResult []string
+			var hLenResult int
+			var hStrLenResult int
+			var hIncrByFloatResult float64
+
+			errCmd := New(getConn()).
+				HSet(&hSetResult, key, []string{"field1", "field2"}, []interface{}{"value1", "1.5"}).
+				HGetAll(&hGetAllResult, key).
+				HMGet(&hMGetResult, key, "field1", "NotExistField").
+				HKeys(&hKeysResult, key).
+				HVals(&hValsResult, key).
+				HLen(&hLenResult, key).
+				HStrLen(&hStrLenResult, key, "field1").
+				HIncrByFloat(&hIncrByFloatResult, key, "field2", 0.25).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(hSetResult).To(Equal(2))
+			Expect(hGetAllResult).To(Equal(map[string]string{"field1": "value1", "field2": "1.5"}))
+			Expect(hMGetResult).To(Equal([]NullString{{String: "value1", Valid: true}, {}}))
+			Expect(hKeysResult).To(ConsistOf("field1", "field2"))
+			Expect(hValsResult).To(ConsistOf("value1", "1.5"))
+			Expect(hLenResult).To(Equal(2))
+			Expect(hStrLenResult).To(Equal(6))
+			Expect(hIncrByFloatResult).To(Equal(1.75))
+		})
+
+		It("should return the real results of a valid HRANDFIELD", func() {
+			key := "SomeKey"
+			var hSetResult int
+			var hRandFieldResult string
+			var hRandFieldCountResult []string
+			var hRandFieldValuesResult map[string]string
+
+			errCmd := New(getConn()).
+				HSet(&hSetResult, key, []string{"field1", "field2"}, []interface{}{"value1", "value2"}).
+				HRandField(&hRandFieldResult, key).
+				HRandField(&hRandFieldCountResult, key, HRandFieldOptionCount{Count: 2}).
+				HRandField(&hRandFieldValuesResult, key, HRandFieldOptionCount{Count: 2, WithValues: true}).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(hSetResult).To(Equal(2))
+			Expect(hRandFieldResult).To(BeElementOf("field1", "field2"))
+			Expect(hRandFieldCountResult).To(ConsistOf("field1", "field2"))
+			Expect(hRandFieldValuesResult).To(Equal(map[string]string{"field1": "value1", "field2": "value2"}))
+		})
+	})
+
+	Describe("Hash structs", func() {
+		type user struct {
+			Name  string  `redis:"name"`
+			Age   int     `redis:"age"`
+			Score float64 `redis:"score"`
+		}
+
+		It("should set and get a hash by the redis tags of a struct", func() {
+			key := "SomeKey"
+			var hSetResult int
+			var hSetOmitZeroResult int
+			var hGetAllResult map[string]string
+			var hGetAllStructResult user
+
+			errCmd := New(getConn()).
+				HSetStruct(&hSetResult, key, user{Name: "SomeName", Age: 30}).
+				HSetStruct(&hSetOmitZeroResult, key, &user{Score: 1.5}, HSetStructOptionOmitZero{}).
+				HGetAll(&hGetAllResult, key).
+				HGetAllStruct(key, &hGetAllStructResult).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(hSetResult).To(Equal(3))
+			Expect(hSetOmitZeroResult).To(Equal(0))
+			Expect(hGetAllResult).To(Equal(map[string]string{"name": "SomeName", "age": "30", "score": "1.5"}))
+			Expect(hGetAllStructResult).To(Equal(user{Name: "SomeName", Age: 30, Score: 1.5}))
+		})
+
+		It("should leave the struct of a missing hash unchanged", func() {
+			hGetAllStructResult := user{Name: "SomeName"}
+
+			errCmd := New(getConn()).
+				HGetAllStruct("NotExistKey", &hGetAllStructResult).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(hGetAllStructResult).To(Equal(user{Name: "SomeName"}))
+		})
+	})
+
+	Describe("Lists", func() {
+		It("should return the real results of valid push and pop commands", func() {
+			key := "SomeKey"
+			var lPushResult int
+			var rPushResult int
+			var lPushXResult int
+			var rPushXMissingResult int
+			var lPopResult string
+			var rPopResult []string
+			var lPopMissingResult interface{}
+			var lLenResult int
+
+			errCmd := New(getConn()).
+				LPush(&lPushResult, key, "b", "a").
+				RPush(&rPushResult, key, "c", "d", "e").
+				LPushX(&lPushXResult, key, "z").
+				RPushX(&rPushXMissingResult, "NotExistKey", "a").
+				LPop(&lPopResult, key).
+				RPop(&rPopResult, key, RPopOptionCount{Count: 2}).
+				LPop(&lPopMissingResult, "NotExistKey").
+				LLen(&lLenResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(lPushResult).To(Equal(2))
+			Expect(rPushResult).To(Equal(5))
+			Expect(lPushXResult).To(Equal(6))
+			Expect(rPushXMissingResult).To(Equal(0))
+			Expect(lPopResult).To(Equal("z"))
+			Expect(rPopResult).To(Equal([]string{"e", "d"}))
+			Expect(lPopMissingResult).To(BeNil())
+			Expect(lLenResult).To(Equal(3))
+		})
+
+		It("should return the real results of valid index commands", func() {
+			key := "SomeKey"
+			var pushResult int
+			var lSetResult string
+			var lInsertResult int
+			var lInsertMissingResult int
+			var lIndexResult string
+			var lRemResult int
+			var lTrimResult string
+			var lRangeResult []string
+
+			errCmd := New(getConn()).
+				RPush(&pushResult, key, "a", "b", "c", "b", "d").
+				LSet(&lSetResult, key, 0, "x").
+				LInsert(&lInsertResult, key, ListPositionAfter, "x", "y").
+				LInsert(&lInsertMissingResult, key, ListPositionBefore, "NotExistPivot", "y").
+				LIndex(&lIndexResult, key, -1).
+				LRem(&lRemResult, key, 0, "b").
+				LTrim(&lTrimResult, key, 0, 2).
+				LRange(&lRangeResult, key, 0, -1).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(pushResult).To(Equal(5))
+			Expect(lSetResult).To(Equal("OK"))
+			Expect(lInsertResult).To(Equal(6))
+			Expect(lInsertMissingResult).To(Equal(-1))
+			Expect(lIndexResult).To(Equal("d"))
+			Expect(lRemResult).To(Equal(2))
+			Expect(lTrimResult).To(Equal("OK"))
+			Expect(lRangeResult).To(Equal([]string{"x", "y", "c"}))
+		})
+
+		It("should return the real results of valid LPOS and LMOVE", func() {
+			key := "SomeKey"
+			destination := "SomeDestination"
+			var pushResult int
+			var lPosResult int
+			var lPosRankResult int
+			var lPosCountResult []int
+			var lMoveResult string
+			var lRangeResult []string
+
+			errCmd := New(getConn()).
+				RPush(&pushResult, key, "a", "b", "a", "c", "a").
+				LPos(&lPosResult, key, "a").
+				LPos(&lPosRankResult, key, "a", LPosOptionRank{Rank: -1}).
+				LPos(&lPosCountResult, key, "a", LPosOptionCount{Count: 0}, LPosOptionMaxLen{MaxLen: 3}).
+				LMove(&lMoveResult, key, destination, ListDirectionRight, ListDirectionLeft).
+				LRange(&lRangeResult, destination, 0, -1).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(pushResult).To(Equal(5))
+			Expect(lPosResult).To(Equal(0))
+			Expect(lPosRankResult).To(Equal(4))
+			Expect(lPosCountResult).To(Equal([]int{0, 2}))
+			Expect(lMoveResult).To(Equal("a"))
+			Expect(lRangeResult).To(Equal([]string{"a"}))
+		})
+	})
+
+	Describe("Blocking pops", func() {
+		It("should return the real results of valid blocking pops", func() {
+			key := "SomeKey"
+			zKey := "SomeZKey"
+			destination := "SomeDestination"
+			var pushResult int
+			var zAddResult int
+			var bLPopResult ListElement
+			var bRPopResult ListElement
+			var bLMoveResult ListElement
+			var bZPopMinResult ZElement
+			var bZPopMaxResult ZElement
+
+			errCmd := New(getConn()).
+				RPush(&pushResult, key, "a", "b", "c").
+				Command(&zAddResult, "ZADD", zKey, 1, "one", 2, "two").
+				BLPop(&bLPopResult, time.Second, "NotExistKey", key).
+				BRPop(&bRPopResult, time.Second, key).
+				BLMove(&bLMoveResult, key, destination, ListDirectionLeft, ListDirectionLeft, time.Second).
+				BZPopMin(&bZPopMinResult, time.Second, zKey).
+				BZPopMax(&bZPopMaxResult, time.Second, zKey).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(pushResult).To(Equal(3))
+			Expect(zAddResult).To(Equal(2))
+			Expect(bLPopResult).To(Equal(ListElement{Key: key, Element: "a"}))
+			Expect(bRPopResult).To(Equal(ListElement{Key: key, Element: "c"}))
+			Expect(bLMoveResult).To(Equal(ListElement{Element: "b"}))
+			Expect(bZPopMinResult).To(Equal(ZElement{Key: zKey, Member: "one", Score: 1}))
+			Expect(bZPopMaxResult).To(Equal(ZElement{Key: zKey, Member: "two", Score: 2}))
+		})
+
+		It("should return a timeout result when a block is longer than the read timeout", func() {
+			config := getCorrectConfig()
+			config.ReadTimeoutSeconds = 1
+			blockingPool, err := bluto.GetPool(config)
+			Expect(err).To(BeNil())
+			defer blockingPool.Close()
+			var bLPopResult ListElement
+			var pingResult string
+
+			start := time.Now()
+			errCmd := New(blockingPool.Get()).
+				BLPop(&bLPopResult, 2*time.Second, "NotExistKey").
+				Ping(&pingResult).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+			Expect(bLPopResult).To(Equal(ListElement{Timeout: true}))
+			Expect(pingResult).To(Equal("PONG"))
+		})
+	})
+
+	Describe("Sets", func() {
+		It("should return the real results of valid member commands", func() {
+			key := "SomeKey"
+			var sAddResult int
+			var sRemResult int
+			var sMembersResult []string
+			var sIsMemberResult bool
+			var sMIsMemberResult []bool
+			var sCardResult int
+
+			errCmd := New(getConn()).
+				SAdd(&sAddResult, key, "a", "b", "c", "a").
+				SRem(&sRemResult, key, "c", "d").
+				SMembers(&sMembersResult, key).
+				SIsMember(&sIsMemberResult, key, "a").
+				SMIsMember(&sMIsMemberResult, key, "a", "c", "b").
+				SCard(&sCardResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(sAddResult).To(Equal(3))
+			Expect(sRemResult).To(Equal(1))
+			Expect(sMembersResult).To(ConsistOf("a", "b"))
+			Expect(sIsMemberResult).To(BeTrue())
+			Expect(sMIsMemberResult).To(Equal([]bool{true, false, true}))
+			Expect(sCardResult).To(Equal(2))
+		})
+
+		It("should return the real results of valid algebra commands", func() {
+			key1 := "SomeKey1"
+			key2 := "SomeKey2"
+			destination := "SomeDestination"
+			var sAddResult int
+			var sInterResult []string
+			var sUnionResult []string
+			var sDiffResult []string
+			var sInterStoreResult int
+			var sUnionStoreResult int
+			var sDiffStoreResult int
+			var sInterCardResult int
+
+			errCmd := New(getConn()).
+				SAdd(&sAddResult, key1, "a", "b", "c").
+				SAdd(&sAddResult, key2, "b", "c", "d").
+				SInter(&sInterResult, key1, key2).
+				SUnion(&sUnionResult, key1, key2).
+				SDiff(&sDiffResult, key1, key2).
+				SInterStore(&sInterStoreResult, destination, key1, key2).
+				SUnionStore(&sUnionStoreResult, destination, key1, key2).
+				SDiffStore(&sDiffStoreResult, destination, key1, key2).
+				SInterCard(&sInterCardResult, []string{key1, key2}, SInterCardOptionLimit{Limit: 1}).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(sInterResult).To(ConsistOf("b", "c"))
+			Expect(sUnionResult).To(ConsistOf("a", "b", "c", "d"))
+			Expect(sDiffResult).To(ConsistOf("a"))
+			Expect(sInterStoreResult).To(Equal(2))
+			Expect(sUnionStoreResult).To(Equal(4))
+			Expect(sDiffStoreResult).To(Equal(1))
+			Expect(sInterCardResult).To(Equal(1))
+		})
+
+		It("should return the real results of valid random and move commands", func() {
+			key := "SomeKey"
+			destination := "SomeDestination"
+			var sAddResult int
+			var sRandMemberResult string
+			var sRandMemberCountResult []string
+			var sPopCountResult []string
+			var sMoveResult bool
+			var sMoveMissingResult bool
+			var sPopResult string
+			var sPopMissingResult interface{}
+
+			errCmd := New(getConn()).
+				SAdd(&sAddResult, key, "a", "b", "c").
+				SRandMember(&sRandMemberResult, key).
+				SRandMember(&sRandMemberCountResult, key, SRandMemberOptionCount{Count: -5}).
+				SPop(&sPopCountResult, key, SPopOptionCount{Count: 2}).
+				SMove(&sMoveMissingResult, key, destination, "NotExistMember").
+				Commit()
+			Expect(errCmd).To(BeNil())
+			Expect([]string{"a", "b", "c"}).To(ContainElement(sRandMemberResult))
+			Expect(sRandMemberCountResult).To(HaveLen(5))
+			Expect(sPopCountResult).To(HaveLen(2))
+			Expect(sMoveMissingResult).To(BeFalse())
+
+			var sMembersResult []string
+			errCmd = New(getConn()).
+				SMembers(&sMembersResult, key).
+				Commit()
+			Expect(errCmd).To(BeNil())
+			Expect(sMembersResult).To(HaveLen(1))
+
+			errCmd = New(getConn()).
+				SMove(&sMoveResult, key, destination, sMembersResult[0]).
+				SPop(&sPopResult, destination).
+				SPop(&sPopMissingResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(sMoveResult).To(BeTrue())
+			Expect(sPopResult).To(Equal(sMembersResult[0]))
+			Expect(sPopMissingResult).To(BeNil())
+		})
+	})
+
+	Describe("Sorted sets", func() {
+		It("should return the real results of valid ZADD and score commands", func() {
+			key := "SomeKey"
+			var zAddResult int
+			var zAddNXResult int
+			var zAddCHResult int
+			var zAddIncrResult float64
+			var zAddIncrMissingResult interface{}
+			var zIncrByResult float64
+			var zScoreResult float64
+			var zScoreMissingResult interface{}
+			var zMScoreResult []interface{}
+			var zRankResult int
+			var zRevRankResult int
+			var zRankMissingResult interface{}
+			var zCardResult int
+			var zCountResult int
+
+			errCmd := New(getConn()).
+				ZAdd(&zAddResult, key, []float64{1, 2, 3}, []interface{}{"a", "b", "c"}).
+				ZAdd(&zAddNXResult, key, []float64{10, 4}, []interface{}{"a", "d"}, ZAddOptionNX{}).
+				ZAdd(&zAddCHResult, key, []float64{0, 5}, []interface{}{"a", "b"}, ZAddOptionGT{}, ZAddOptionCH{}).
+				ZAdd(&zAddIncrResult, key, []float64{2}, []interface{}{"c"}, ZAddOptionINCR{}).
+				ZAdd(&zAddIncrMissingResult, key, []float64{2}, []interface{}{"e"}, ZAddOptionXX{}, ZAddOptionINCR{}).
+				ZIncrBy(&zIncrByResult, key, 1.5, "d").
+				ZScore(&zScoreResult, key, "d").
+				ZScore(&zScoreMissingResult, key, "e").
+				ZMScore(&zMScoreResult, key, "a", "e").
+				ZRank(&zRankResult, key, "a").
+				ZRevRank(&zRevRankResult, key, "a").
+				ZRank(&zRankMissingResult, key, "e").
+				ZCard(&zCardResult, key).
+				ZCount(&zCountResult, key, "(1", "+inf").
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(zAddResult).To(Equal(3))
+			Expect(zAddNXResult).To(Equal(1))
+			Expect(zAddCHResult).To(Equal(1))
+			Expect(zAddIncrResult).To(Equal(float64(5)))
+			Expect(zAddIncrMissingResult).To(BeNil())
+			Expect(zIncrByResult).To(Equal(5.5))
+			Expect(zScoreResult).To(Equal(5.5))
+			Expect(zScoreMissingResult).To(BeNil())
+			Expect(zMScoreResult).To(Equal([]interface{}{[]byte("1"), nil}))
+			Expect(zRankResult).To(Equal(0))
+			Expect(zRevRankResult).To(Equal(3))
+			Expect(zRankMissingResult).To(BeNil())
+			Expect(zCardResult).To(Equal(4))
+			Expect(zCountResult).To(Equal(3))
+		})
+
+		It("should return the real results of valid ZRANGE", func() {
+			key := "SomeKey"
+			var zAddResult int
+			var zRangeResult []string
+			var zRangeByScoreResult []string
+			var zRangeByLexResult []string
+			var zRangeWithScoresResult []string
+
+			errCmd := New(getConn()).
+				ZAdd(&zAddResult, key, []float64{1, 2, 3, 4}, []interface{}{"a", "b", "c", "d"}).
+				ZRange(&zRangeResult, key, 0, -1, ZRangeOptionRev{}).
+				ZRange(&zRangeByScoreResult, key, "(1", "+inf", ZRangeOptionByScore{}, ZRangeOptionLimit{Offset: 1, Count: 2}).
+				ZRange(&zRangeByLexResult, key, "[c", "-", ZRangeOptionByLex{}, ZRangeOptionRev{}).
+				ZRange(&zRangeWithScoresResult, key, 0, 1, ZRangeOptionWithScores{}).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(zAddResult).To(Equal(4))
+			Expect(zRangeResult).To(Equal([]string{"d", "c", "b", "a"}))
+			Expect(zRangeByScoreResult).To(Equal([]string{"c", "d"}))
+			Expect(zRangeByLexResult).To(Equal([]string{"c", "b", "a"}))
+			Expect(zRangeWithScoresResult).To(Equal([]string{"a", "1", "b", "2"}))
+		})
+
+		It("should return the real results of valid remove and pop commands", func() {
+			key := "SomeKey"
+			var zAddResult int
+			var zRemResult int
+			var zRemRangeByScoreResult int
+			var zRemRangeByRankResult int
+			var zRemRangeByLexResult int
+			var zPopMinResult []string
+			var zPopMaxResult []string
+			var zRangeResult []string
+
+			errCmd := New(getConn()).
+				ZAdd(&zAddResult, key, []float64{1, 2, 3, 4, 5, 6, 7, 8}, []interface{}{"a", "b", "c", "d", "e", "f", "g", "h"}).
+				ZRem(&zRemResult, key, "a", "NotExistMember").
+				ZRemRangeByScore(&zRemRangeByScoreResult, key, 2, "(4").
+				ZRemRangeByRank(&zRemRangeByRankResult, key, -1, -1).
+				ZPopMin(&zPopMinResult, key).
+				ZPopMax(&zPopMaxResult, key, ZPopMaxOptionCount{Count: 2}).
+				ZRemRangeByLex(&zRemRangeByLexResult, key, "-", "+").
+				ZRange(&zRangeResult, key, 0, -1).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(zAddResult).To(Equal(8))
+			Expect(zRemResult).To(Equal(1))
+			Expect(zRemRangeByScoreResult).To(Equal(2))
+			Expect(zRemRangeByRankResult).To(Equal(1))
+			Expect(zPopMinResult).To(Equal([]string{"d", "4"}))
+			Expect(zPopMaxResult).To(Equal([]string{"g", "7", "f", "6"}))
+			Expect(zRemRangeByLexResult).To(Equal(1))
+			Expect(zRangeResult).To(BeEmpty())
+		})
+
+		It("should return the real results of valid ZUNION and ZINTER", func() {
+			key1 := "SomeKey1"
+			key2 := "SomeKey2"
+			var zAddResult int
+			var zUnionResult []string
+			var zInterResult []string
+
+			errCmd := New(getConn()).
+				ZAdd(&zAddResult, key1, []float64{1, 2}, []interface{}{"a", "b"}).
+				ZAdd(&zAddResult, key2, []float64{3, 4}, []interface{}{"b", "c"}).
+				ZUnion(&zUnionResult, []string{key1, key2}, ZCombineOptionWeights{Weights: []float64{2, 1}}, ZCombineOptionWithScores{}).
+				ZInter(&zInterResult, []string{key1, key2}, ZCombineOptionAggregate{Aggregate: ZAggregateMax}, ZCombineOptionWithScores{}).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(zUnionResult).To(Equal([]string{"a", "2", "c", "4", "b", "7"}))
+			Expect(zInterResult).To(Equal([]string{"b", "3"}))
+		})
+	})
+
+	Describe("ZMember", func() {
+		It("should return the members and scores of the WITHSCORES replies", func() {
+			key1 := "SomeKey1"
+			key2 := "SomeKey2"
+			var zAddResult int
+			var zRangeResult []ZMember
+			var zUnionResult []ZMember
+			var zPopMinResult []ZMember
+
+			errCmd := New(getConn()).
+				ZAdd(&zAddResult, key1, []float64{1, 2.5}, []interface{}{"a", "b"}).
+				ZAdd(&zAddResult, key2, []float64{3}, []interface{}{"b"}).
+				ZRange(&zRangeResult, key1, 0, -1, ZRangeOptionWithScores{}).
+				ZUnion(&zUnionResult, []string{key1, key2}, ZCombineOptionWithScores{}).
+				ZPopMin(&zPopMinResult, key1).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(zRangeResult).To(Equal([]ZMember{{Member: "a", Score: 1}, {Member: "b", Score: 2.5}}))
+			Expect(zUnionResult).To(Equal([]ZMember{{Member: "a", Score: 1}, {Member: "b", Score: 5.5}}))
+			Expect(zPopMinResult).To(Equal([]ZMember{{Member: "a", Score: 1}}))
+		})
+	})
+
+	Describe("Strings", func() {
+		It("should return the real results of valid multi key commands", func() {
+			var mSetResult string
+			var mSetNXResult bool
+			var mSetNXExistResult bool
+			var mGetResult []NullString
+			var mGetValuesResult []string
+
+			errCmd := New(getConn()).
+				MSet(&mSetResult, []string{"SomeKey1", "SomeKey2"}, []interface{}{"a", 1}).
+				MSetNX(&mSetNXResult, []string{"SomeKey3", "SomeKey4"}, []interface{}{"c", "d"}).
+				MSetNX(&mSetNXExistResult, []string{"SomeKey1", "SomeKey5"}, []interface{}{"x", "y"}).
+				MGet(&mGetResult, "SomeKey1", "NotExistKey", "SomeKey4").
+				MGet(&mGetValuesResult, "SomeKey2", "SomeKey3").
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(mSetResult).To(Equal("OK"))
+			Expect(mSetNXResult).To(BeTrue())
+			Expect(mSetNXExistResult).To(BeFalse())
+			Expect(mGetResult).To(Equal([]NullString{{String: "a", Valid: true}, {}, {String: "d", Valid: true}}))
+			Expect(mGetValuesResult).To(Equal([]string{"1", "c"}))
+		})
+
+		It("should return the real results of valid string commands", func() {
+			key := "SomeKey"
+			var setResult string
+			var appendResult int
+			var strLenResult int
+			var getRangeResult string
+			var setRangeResult int
+			var getResult string
+
+			errCmd := New(getConn()).
+				Set(&setResult, key, "Hello").
+				Append(&appendResult, key, " World").
+				StrLen(&strLenResult, key).
+				GetRange(&getRangeResult, key, -5, -1).
+				SetRange(&setRangeResult, key, 6, "Redis").
+				Get(&getResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(setResult).To(Equal("OK"))
+			Expect(appendResult).To(Equal(11))
+			Expect(strLenResult).To(Equal(11))
+			Expect(getRangeResult).To(Equal("World"))
+			Expect(setRangeResult).To(Equal(11))
+			Expect(getResult).To(Equal("Hello Redis"))
+		})
+
+		It("should return the real results of valid number commands", func() {
+			key := "SomeKey"
+			floatKey := "SomeFloatKey"
+			var incrByResult int64
+			var decrByResult int64
+			var incrByFloatResult float64
+
+			errCmd := New(getConn()).
+				IncrBy(&incrByResult, key, 10).
+				DecrBy(&decrByResult, key, 3).
+				IncrByFloat(&incrByFloatResult, floatKey, 2.5).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(incrByResult).To(Equal(int64(10)))
+			Expect(decrByResult).To(Equal(int64(7)))
+			Expect(incrByFloatResult).To(Equal(2.5))
+		})
+
+		It("should return the real results of valid get and set commands", func() {
+			key := "SomeKey"
+			var setResult string
+			var setGetResult string
+			var getSetResult string
+			var getSetMissingResult interface{}
+			var getExResult string
+			var ttlResult int
+			var getExPersistResult string
+			var persistTTLResult int
+			var getDelResult string
+			var existsResult int
+
+			errCmd := New(getConn()).
+				Set(&setResult, key, "a", SetOptionEXAT{EXAT: uint64(time.Now().Add(time.Hour).Unix())}).
+				Set(&setGetResult, key, "b", SetOptionGET{}).
+				GetSet(&getSetResult, key, "c").
+				GetSet(&getSetMissingResult, "NotExistKey", "c").
+				GetEx(&getExResult, key, GetExOptionEX{EX: 100}).
+				Command(&ttlResult, "TTL", key).
+				GetEx(&getExPersistResult, key, GetExOptionPersist{}).
+				Command(&persistTTLResult, "TTL", key).
+				GetDel(&getDelResult, key).
+				Exists(&existsResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(setResult).To(Equal("OK"))
+			Expect(setGetResult).To(Equal("a"))
+			Expect(getSetResult).To(Equal("b"))
+			Expect(getSetMissingResult).To(BeNil())
+			Expect(getExResult).To(Equal("c"))
+			Expect(ttlResult).To(Equal(100))
+			Expect(getExPersistResult).To(Equal("c"))
+			Expect(persistTTLResult).To(Equal(-1))
+			Expect(getDelResult).To(Equal("c"))
+			Expect(existsResult).To(Equal(0))
+		})
+	})
+
+	Describe("Bitmaps", func() {
+		It("should return the real results of valid bitmap commands", func() {
+			key1 := "SomeKey1"
+			key2 := "SomeKey2"
+			destination := "SomeDestination"
+			var setBitResult int
+			var setBitAgainResult int
+			var getBitResult int
+			var bitCountResult int
+			var bitCountRangeResult int
+			var bitPosResult int
+			var bitPosRangeResult int
+			var bitOpResult int
+			var bitOpCountResult int
+
+			errCmd := New(getConn()).
+				SetBit(&setBitResult, key1, 1, 1).
+				SetBit(&setBitAgainResult, key1, 1, 1).
+				SetBit(&setBitResult, key1, 9, 1).
+				SetBit(&setBitResult, key2, 9, 1).
+				GetBit(&getBitResult, key1, 9).
+				BitCount(&bitCountResult, key1).
+				BitCount(&bitCountRangeResult, key1, BitCountOptionRange{Start: 1, End: 1}).
+				BitPos(&bitPosResult, key1, 1).
+				BitPos(&bitPosRangeResult, key1, 1, BitPosOptionStart{Start: 1}).
+				BitOp(&bitOpResult, BitOperationAnd, destination, key1, key2).
+				BitCount(&bitOpCountResult, destination).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(setBitAgainResult).To(Equal(1))
+			Expect(getBitResult).To(Equal(1))
+			Expect(bitCountResult).To(Equal(2))
+			Expect(bitCountRangeResult).To(Equal(1))
+			Expect(bitPosResult).To(Equal(1))
+			Expect(bitPosRangeResult).To(Equal(9))
+			Expect(bitOpResult).To(Equal(2))
+			Expect(bitOpCountResult).To(Equal(1))
+		})
+	})
+
+	Describe("HyperLogLogs", func() {
+		It("should return the real results of valid HyperLogLog commands", func() {
+			key1 := "SomeKey1"
+			key2 := "SomeKey2"
+			destination := "SomeDestination"
+			var pfAddResult bool
+			var pfAddAgainResult bool
+			var pfCountResult int
+			var pfCountMultiResult int
+			var pfMergeResult string
+			var pfCountMergeResult int
+
+			errCmd := New(getConn()).
+				PFAdd(&pfAddResult, key1, "a", "b", "c").
+				PFAdd(&pfAddAgainResult, key1, "a").
+				PFAdd(&pfAddResult, key2, "d", "e").
+				PFCount(&pfCountResult, key1).
+				PFCount(&pfCountMultiResult, key1, key2).
+				PFMerge(&pfMergeResult, destination, key1, key2).
+				PFCount(&pfCountMergeResult, destination).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(pfAddResult).To(BeTrue())
+			Expect(pfAddAgainResult).To(BeFalse())
+			Expect(pfCountResult).To(Equal(3))
+			Expect(pfCountMultiResult).To(Equal(5))
+			Expect(pfMergeResult).To(Equal("OK"))
+			Expect(pfCountMergeResult).To(Equal(5))
+		})
+	})
+
+	Describe("Geospatial", func() {
+		It("should return the real results of valid geospatial commands", func() {
+			key := "SomeKey"
+			locations := []GeoLocation{
+				{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+				{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+			}
+			var geoAddResult int
+			var geoDistResult float64
+			var geoDistMissingResult interface{}
+			var geoPosResult []*GeoLocation
+			var geoSearchResult []GeoLocation
+			var geoSearchWithResult []GeoLocation
+
+			errCmd := New(getConn()).
+				GeoAdd(&geoAddResult, key, locations).
+				GeoDist(&geoDistResult, key, "Palermo", "Catania", GeoDistOptionUnit{Unit: GeoUnitKilometers}).
+				GeoDist(&geoDistMissingResult, key, "Palermo", "Rome").
+				GeoPos(&geoPosResult, key, "Palermo", "Rome").
+				GeoSearch(&geoSearchResult, key, GeoFromLonLat{Longitude: 15, Latitude: 37}, GeoByRadius{Radius: 200, Unit: GeoUnitKilometers}, GeoSearchOptionAsc{}).
+				GeoSearch(&geoSearchWithResult, key, GeoFromMember{Member: "Palermo"}, GeoByRadius{Radius: 200, Unit: GeoUnitKilometers},
+					GeoSearchOptionDesc{}, GeoSearchOptionWithCoord{}, GeoSearchOptionWithDist{}).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(geoAddResult).To(Equal(2))
+			Expect(geoDistResult).To(BeNumerically("~", 166.27, 0.01))
+			Expect(geoDistMissingResult).To(BeNil())
+			Expect(geoPosResult).To(HaveLen(2))
+			Expect(geoPosResult[0].Name).To(Equal("Palermo"))
+			Expect(geoPosResult[0].Longitude).To(BeNumerically("~", 13.361389, 0.0001))
+			Expect(geoPosResult[0].Latitude).To(BeNumerically("~", 38.115556, 0.0001))
+			Expect(geoPosResult[1]).To(BeNil())
+			Expect(geoSearchResult).To(Equal([]GeoLocation{{Name: "Catania"}, {Name: "Palermo"}}))
+			Expect(geoSearchWithResult).To(HaveLen(2))
+			Expect(geoSearchWithResult[0].Name).To(Equal("Catania"))
+			Expect(geoSearchWithResult[0].Dist).To(BeNumerically("~", 166.27, 0.01))
+			Expect(geoSearchWithResult[0].Longitude).To(BeNumerically("~", 15.087269, 0.0001))
+			Expect(geoSearchWithResult[1].Name).To(Equal("Palermo"))
+			Expect(geoSearchWithResult[1].Dist).To(BeNumerically("~", 0, 0.01))
+		})
+	})
+
+	Describe("Keys management", func() {
+		It("should return the real results of valid expiry commands", func() {
+			key := "SomeKey"
+			var setResult string
+			var ttlNoExpiryResult time.Duration
+			var ttlMissingResult time.Duration
+			var pExpireResult bool
+			var pttlResult time.Duration
+			var expireAtResult bool
+			var ttlResult time.Duration
+			var expireTimeResult int64
+			var pExpireAtResult bool
+			var persistResult bool
+			var persistTTLResult time.Duration
+
+			expireAt := time.Now().Add(time.Hour).Unix()
+			errCmd := New(getConn()).
+				Set(&setResult, key, "SomeValue").
+				TTL(&ttlNoExpiryResult, key).
+				TTL(&ttlMissingResult, "NotExistKey").
+				PExpire(&pExpireResult, key, 100000).
+				PTTL(&pttlResult, key).
+				ExpireAt(&expireAtResult, key, expireAt).
+				TTL(&ttlResult, key).
+				ExpireTime(&expireTimeResult, key).
+				PExpireAt(&pExpireAtResult, "NotExistKey", expireAt*1000).
+				Persist(&persistResult, key).
+				TTL(&persistTTLResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(ttlNoExpiryResult).To(Equal(TTLNoExpiry))
+			Expect(ttlMissingResult).To(Equal(TTLMissingKey))
+			Expect(pExpireResult).To(BeTrue())
+			Expect(pttlResult).To(BeNumerically("~", 100*time.Second, time.Second))
+			Expect(expireAtResult).To(BeTrue())
+			Expect(ttlResult).To(BeNumerically("~", time.Hour, 2*time.Second))
+			Expect(expireTimeResult).To(Equal(expireAt))
+			Expect(pExpireAtResult).To(BeFalse())
+			Expect(persistResult).To(BeTrue())
+			Expect(persistTTLResult).To(Equal(TTLNoExpiry))
+		})
+
+		It("should return the real results of valid key commands", func() {
+			key := "SomeKey"
+			var setResult string
+			var renameResult string
+			var renameNXResult bool
+			var typeResult string
+			var touchResult int
+			var copyResult bool
+			var copyExistResult bool
+			var idleTimeResult int64
+			var dumpResult []byte
+			var unlinkResult int
+
+			errCmd := New(getConn()).
+				Set(&setResult, key, "SomeValue").
+				Set(&setResult, "OtherKey", "OtherValue").
+				Rename(&renameResult, key, "RenamedKey").
+				RenameNX(&renameNXResult, "RenamedKey", "OtherKey").
+				Type(&typeResult, "RenamedKey").
+				Touch(&touchResult, "RenamedKey", "OtherKey", "NotExistKey").
+				Copy(&copyResult, "RenamedKey", "CopiedKey").
+				Copy(&copyExistResult, "RenamedKey", "OtherKey").
+				ObjectIdleTime(&idleTimeResult, "RenamedKey").
+				Dump(&dumpResult, "RenamedKey").
+				Unlink(&unlinkResult, "RenamedKey", "OtherKey", "NotExistKey").
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(renameResult).To(Equal("OK"))
+			Expect(renameNXResult).To(BeFalse())
+			Expect(typeResult).To(Equal("string"))
+			Expect(touchResult).To(Equal(2))
+			Expect(copyResult).To(BeTrue())
+			Expect(copyExistResult).To(BeFalse())
+			Expect(idleTimeResult).To(BeNumerically(">=", 0))
+			Expect(dumpResult).To(Not(BeEmpty()))
+			Expect(unlinkResult).To(Equal(2))
+
+			var restoreResult string
+			var getResult string
+			var pttlResult time.Duration
+			errCmd = New(getConn()).
+				Restore(&restoreResult, key, 10000, dumpResult).
+				Restore(&restoreResult, "CopiedKey", 0, dumpResult, RestoreOptionReplace{}).
+				Get(&getResult, key).
+				PTTL(&pttlResult, key).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(restoreResult).To(Equal("OK"))
+			Expect(getResult).To(Equal("SomeValue"))
+			Expect(pttlResult).To(BeNumerically("~", 10*time.Second, time.Second))
+		})
+	})
+
+	Describe("CommitReport", func() {
+		It("should report the outcome of each command of the chain", func() {
+			conn := getConn()
+			commander := New(conn)
+			key := "SomeKey"
+			var setResult string
+			var hSetResult1 int
+			var hSetResult2 int
+			var getResult string
+
+			report, errCmd := commander.
+				Set(&setResult, key, "value").
+				HSet(&hSetResult1, key, []string{"field"}, []interface{}{"value"}).
+				HSet(&hSetResult2, "OtherKey", []string{"field"}, []interface{}{"value"}).
+				Get(&getResult, key).
+				CommitReport()
+
+			Expect(errCmd).To(BeNil())
+			Expect(report).To(HaveLen(4))
+			Expect(report[0]).To(Equal(CommandResult{Index: 0, Name: "SET"}))
+			Expect(report[1].Index).To(Equal(1))
+			Expect(report[1].Name).To(Equal("HSET"))
+			Expect(report[1].Err).To(Not(BeNil()))
+			Expect(report[2]).To(Equal(CommandResult{Index: 2, Name: "HSET"}))
+			Expect(report[3]).To(Equal(CommandResult{Index: 3, Name: "GET"}))
+			Expect(setResult).To(Equal("OK"))
+			Expect(hSetResult1).To(Equal(0))
+			Expect(hSetResult2).To(Equal(1))
+			Expect(getResult).To(Equal("value"))
+		})
+
+		It("should return the error of an invalid redis config", func() {
+			conn := getWrongConn()
+			commander := New(conn)
+			var selectResult string
+			report, errCmd := commander.
+				Select(&selectResult, 0).
+				CommitReport()
+
+			Expect(errCmd).To(Not(BeNil()))
+			Expect(report).To(BeNil())
+			Expect(selectResult).To(Equal(""))
+		})
+	})
+
+	Describe("MULTI/EXEC", func() {
+		It("should return the results of a valid transaction", func() {
+			conn := getConn()
+			commander := New(conn)
+			key := "SomeKey"
+			var selectResult string
+			var setResult string
+			var incrResult int64
+			var getResult int
+
+			errCmd := commander.
+				Select(&selectResult, 0).
+				Multi().
+				Set(&setResult, key, 9).
+				Incr(&incrResult, key).
+				Get(&getResult, key).
+				Exec()
+
+			Expect(errCmd).To(BeNil())
+			Expect(selectResult).To(Equal("OK"))
+			Expect(setResult).To(Equal("OK"))
+			Expect(incrResult).To(Equal(int64(10)))
+			Expect(getResult).To(Equal(10))
+		})
+
+		It("should return the error of an aborted transaction", func() {
+			key := "SomeKey"
+			tx := NewTx(getConn())
+			defer tx.Close()
+			errWatch := tx.Watch(key)
+			conn := getConn()
+			var setResult string
+			errSet := New(conn).Set(&setResult, key, 1).Commit()
+			var incrResult int64
+			errCmd := tx.Multi().
+				Incr(&incrResult, key).
+				Exec()
+
+			Expect(errWatch).To(BeNil())
+			Expect(errSet).To(BeNil())
+			Expect(errCmd).To(Equal(ErrTxAborted))
+			Expect(incrResult).To(Equal(int64(0)))
+		})
+	})
+
+	Describe("Scripts", func() {
+		It("should return the results of EVAL, SCRIPT LOAD and EVALSHA", func() {
+			conn := getConn()
+			commander := New(conn)
+			key := "SomeKey"
+			src := "return redis.call('INCRBY', KEYS[1], ARGV[1])"
+			var evalResult int
+			var loadResult string
+			var evalShaResult int
+
+			errCmd := commander.
+				Eval(&evalResult, src, []string{key}, 2).
+				ScriptLoad(&loadResult, src).
+				EvalSha(&evalShaResult, NewScript(src).Hash(), []string{key}, 3).
+				Commit()
+
+			Expect(errCmd).To(BeNil())
+			Expect(evalResult).To(Equal(2))
+			Expect(loadResult).To(Equal(NewScript(src).Hash()))
+			Expect(evalShaResult).To(Equal(5))
+		})
+
+		It("should load and run a script which is not loaded", func() {
+			conn := getConn()
+			commander := New(conn)
+			key := "SomeKey"
+			script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1]) + 0")
+			var flushResult string
+			errFlush := New(getConn()).Command(&flushResult, "SCRIPT", "FLUSH").Commit()
+			var scriptResult1 int
+			var scriptResult2 int
+
+			errCmd1 := commander.
+				RunScript(&scriptResult1, script, []string{key}, 2).
+				Commit()
+			errCmd2 := New(getConn()).
+				RunScript(&scriptResult2, script, []string{key}, 3).
+				Commit()
+
+			Expect(errFlush).To(BeNil())
+			Expect(errCmd1).To(BeNil())
+			Expect(errCmd2).To(BeNil())
+			Expect(scriptResult1).To(Equal(2))
+			Expect(scriptResult2).To(Equal(5))
+		})
+
+		It("should load the script of a transaction before MULTI", func() {
+			key := "SomeKey"
+			script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1]) + 0")
+			var flushResult string
+			errFlush := New(getConn()).Command(&flushResult, "SCRIPT", "FLUSH").Commit()
+			var scriptResult int
+			var getResult int
+
+			errCmd := New(getConn()).
+				Multi().
+				RunScript(&scriptResult, script, []string{key}, 2).
+				Get(&getResult, key).
+				Commit()
+
+			Expect(errFlush).To(BeNil())
+			Expect(errCmd).To(BeNil())
+			Expect(scriptResult).To(Equal(2))
+			Expect(getResult).To(Equal(2))
+		})
+	})
+
+	Describe("Exec and Release", func() {
+		It("should keep the connection and its state between the rounds", func() {
+			conn := getConn()
+			commander := New(conn)
+			key := "SomeKey"
+			var selectResult string
+			var setResult string
+			var getResult int
+			var incrResult int64
+
+			errSet := commander.
+				Select(&selectResult, 1).
+				Set(&setResult, key, 1).
+				Exec()
+			errGet := commander.
+				Get(&getResult, key).
+				Exec()
+			errIncr := commander.
+				Incr(&incrResult, key).
+				Exec()
+			errRelease := commander.Release()
+
+			Expect(errSet).To(BeNil())
+			Expect(selectResult).To(Equal("OK"))
+			Expect(setResult).To(Equal("OK"))
+			Expect(errGet).To(BeNil())
+			Expect(getResult).To(Equal(1))
+			Expect(errIncr).To(BeNil())
+			Expect(incrResult).To(Equal(int64(2)))
+			Expect(errRelease).To(BeNil())
+		})
+
+		It("should reset the error of the last round", func() {
+			conn := getConn()
+			commander := New(conn)
+			key := "SomeKey"
+			var setResult string
+			var getResult string
+
+			errNested := commander.
+				Multi().
+				Multi().
+				Set(&setResult, key, "SomeValue").
+				Exec()
+			errSet := commander.
+				Set(&setResult, key, "SomeValue").
+				Get(&getResult, key).
+				Commit()
+
+			Expect(errNested).To(Not(BeNil()))
+			Expect(errSet).To(BeNil())
+			Expect(setResult).To(Equal("OK"))
+			Expect(getResult).To(Equal("SomeValue"))
+		})
+	})
+
 	Describe("Integration test command and commit", func() {
 		It("should return the error of resuing closed connection", func() {
 			pool, errpool := bluto.GetPool(getCorrectConfig())
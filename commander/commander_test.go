@@ -1,10 +1,13 @@
 package commander
 
 import (
+	"context"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
+	"github.com/gomodule/redigo/redis"
 	"github.com/rafaeljusto/redigomock"
 
 	"github.com/stretchr/testify/assert"
@@ -240,3 +243,935 @@ func TestXadd(t *testing.T) {
 	assert.Nil(t, errCmd)
 	assert.Equal(t, xaddResult, "OK")
 }
+
+func TestCommitContext(t *testing.T) {
+	key := "SomeKey"
+	value := faker.Word()
+	conn := redigomock.NewConn()
+	conn.Command("GET", key).Expect(value)
+	cmd := New(conn)
+	var getResult string
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	errCmd := cmd.
+		Get(&getResult, key).
+		CommitContext(ctx)
+	assert.Nil(t, errCmd)
+	assert.Equal(t, getResult, value)
+}
+
+func TestCommitContextCanceled(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("GET", key).Expect(faker.Word())
+	cmd := New(conn)
+	var getResult string
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errCmd := cmd.
+		Get(&getResult, key).
+		CommitContext(ctx)
+	assert.Equal(t, errCmd, context.Canceled)
+	assert.Equal(t, getResult, "")
+}
+
+func TestCommitContextDeadlineExceeded(t *testing.T) {
+	key := "SomeKey"
+	release := make(chan struct{})
+	defer close(release)
+	conn := redigomock.NewConn()
+	conn.Command("GET", key).Handle(func(args []interface{}) (interface{}, error) {
+		<-release
+		return []byte("SomeValue"), nil
+	})
+	cmd := New(conn)
+	var getResult string
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	errCmd := cmd.
+		Get(&getResult, key).
+		CommitContext(ctx)
+	assert.Equal(t, errCmd, context.DeadlineExceeded)
+	assert.Equal(t, getResult, "")
+}
+
+func TestCommitReport(t *testing.T) {
+	key := "SomeKey"
+	value := faker.Word()
+	conn := redigomock.NewConn()
+	conn.Command("HSET", key, "field", value).Expect(redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	conn.Command("GET", key).Expect(value)
+	cmd := New(conn)
+	var hSetResult int
+	var getResult string
+	report, errCmd := cmd.
+		HSet(&hSetResult, key, []string{"field"}, []interface{}{value}).
+		Get(&getResult, key).
+		CommitReport()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, report, []CommandResult{
+		{Index: 0, Name: "HSET", Err: redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value")},
+		{Index: 1, Name: "GET"},
+	})
+	assert.Equal(t, hSetResult, 0)
+	assert.Equal(t, getResult, value)
+}
+
+func TestCommitReportEmpty(t *testing.T) {
+	conn := redigomock.NewConn()
+	report, errCmd := New(conn).CommitReport()
+	assert.Nil(t, errCmd)
+	assert.Empty(t, report)
+}
+
+func TestMultiExec(t *testing.T) {
+	key := "SomeKey"
+	value := faker.Word()
+	conn := redigomock.NewConn()
+	conn.Command("GET", key).Expect(value)
+	conn.Command("MULTI").Expect("OK")
+	conn.Command("INCR", key).Expect("QUEUED")
+	conn.Command("SET", key, value).Expect("QUEUED")
+	conn.Command("EXEC").ExpectSlice(int64(1), "OK")
+	cmd := New(conn)
+	var getResult string
+	var incrResult int64
+	var setResult string
+	errCmd := cmd.
+		Get(&getResult, key).
+		Multi().
+		Incr(&incrResult, key).
+		Set(&setResult, key, value).
+		Exec()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, getResult, value)
+	assert.Equal(t, incrResult, int64(1))
+	assert.Equal(t, setResult, "OK")
+}
+
+func TestMultiExecAborted(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("MULTI").Expect("OK")
+	conn.Command("INCR", key).Expect("QUEUED")
+	conn.Command("EXEC").Expect(nil)
+	cmd := New(conn)
+	var incrResult int64
+	errCmd := cmd.
+		Multi().
+		Incr(&incrResult, key).
+		Exec()
+	assert.Equal(t, errCmd, ErrTxAborted)
+	assert.Equal(t, incrResult, int64(0))
+}
+
+func TestExecKeepsConnection(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	closed := 0
+	conn.CloseMock = func() error {
+		closed++
+		return nil
+	}
+	conn.Command("GET", key).Expect([]byte("1"))
+	conn.Command("INCR", key).Expect(int64(2))
+	cmd := New(conn)
+	var getResult int
+	errGet := cmd.
+		Get(&getResult, key).
+		Exec()
+	assert.Nil(t, errGet)
+	assert.Equal(t, getResult, 1)
+	assert.Equal(t, closed, 0)
+	var incrResult int64
+	errIncr := cmd.
+		Incr(&incrResult, key).
+		Exec()
+	assert.Nil(t, errIncr)
+	assert.Equal(t, incrResult, int64(2))
+	assert.Equal(t, closed, 0)
+	errRelease := cmd.Release()
+	assert.Nil(t, errRelease)
+	assert.Equal(t, closed, 1)
+}
+
+func TestExecResetsError(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("MULTI").Expect("OK")
+	conn.Command("DISCARD").Expect("OK")
+	conn.Command("GET", key).Expect("SomeValue")
+	cmd := New(conn)
+	var multiResult int64
+	errNested := cmd.
+		Multi().
+		Multi().
+		Incr(&multiResult, key).
+		Exec()
+	assert.NotNil(t, errNested)
+	var getResult string
+	errGet := cmd.
+		Get(&getResult, key).
+		Commit()
+	assert.Nil(t, errGet)
+	assert.Equal(t, getResult, "SomeValue")
+}
+
+// recordCommand registers the reply of the command on the mock connection and records its name when it is run
+func recordCommand(conn *redigomock.Conn, names *[]string, reply interface{}, name string, args ...interface{}) *redigomock.Cmd {
+	return conn.Command(name, args...).Handle(func(args []interface{}) (interface{}, error) {
+		*names = append(*names, name)
+		return reply, nil
+	})
+}
+
+func TestRunScript(t *testing.T) {
+	key := "SomeKey"
+	script := NewScript("return redis.call('GET', KEYS[1])")
+	conn := redigomock.NewConn()
+	conn.Command("SCRIPT", "LOAD", script.Src()).Expect(script.Hash())
+	conn.Command("EVALSHA", script.Hash(), 1, key).Expect("SomeValue")
+	cmd := New(conn)
+	var scriptResult string
+	errCmd := cmd.
+		RunScript(&scriptResult, script, []string{key}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, scriptResult, "SomeValue")
+}
+
+func TestRunScriptLoadOnce(t *testing.T) {
+	key := "SomeKey"
+	script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])")
+	conn := redigomock.NewConn()
+	load := conn.Command("SCRIPT", "LOAD", script.Src()).Expect(script.Hash())
+	conn.Command("GET", key).Expect([]byte("1"))
+	conn.Command("EVALSHA", script.Hash(), 1, key, 2).Expect(int64(3))
+	conn.Command("EVALSHA", script.Hash(), 1, key, 3).Expect(int64(6))
+	cmd := New(conn)
+	var getResult int
+	var scriptResult1 int
+	var scriptResult2 int
+	var scriptResult3 int
+	errCmd1 := cmd.
+		Get(&getResult, key).
+		RunScript(&scriptResult1, script, []string{key}, 2).
+		RunScript(&scriptResult2, script, []string{key}, 3).
+		Exec()
+	errCmd2 := cmd.
+		RunScript(&scriptResult3, script, []string{key}, 3).
+		Commit()
+	assert.Nil(t, errCmd1)
+	assert.Nil(t, errCmd2)
+	assert.Equal(t, getResult, 1)
+	assert.Equal(t, scriptResult1, 3)
+	assert.Equal(t, scriptResult2, 6)
+	assert.Equal(t, scriptResult3, 6)
+	assert.Equal(t, conn.Stats(load), 1)
+}
+
+func TestRunScriptLoadOrder(t *testing.T) {
+	key := "SomeKey"
+	script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])")
+	conn := redigomock.NewConn()
+	var names []string
+	recordCommand(conn, &names, script.Hash(), "SCRIPT", "LOAD", script.Src())
+	recordCommand(conn, &names, int64(3), "EVALSHA", script.Hash(), 1, key, 2)
+	recordCommand(conn, &names, []byte("3"), "GET", key)
+	cmd := New(conn)
+	var scriptResult int
+	var getResult int
+	report, errCmd := cmd.
+		RunScript(&scriptResult, script, []string{key}, 2).
+		Get(&getResult, key).
+		CommitReport()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, names, []string{"SCRIPT", "EVALSHA", "GET"})
+	assert.Equal(t, report, []CommandResult{{Index: 0, Name: "EVALSHA"}, {Index: 1, Name: "GET"}})
+	assert.Equal(t, scriptResult, 3)
+	assert.Equal(t, getResult, 3)
+}
+
+func TestRunScriptLoadMulti(t *testing.T) {
+	key := "SomeKey"
+	script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])")
+	conn := redigomock.NewConn()
+	var names []string
+	recordCommand(conn, &names, []byte("1"), "GET", key)
+	recordCommand(conn, &names, script.Hash(), "SCRIPT", "LOAD", script.Src())
+	recordCommand(conn, &names, "OK", "MULTI")
+	recordCommand(conn, &names, "QUEUED", "EVALSHA", script.Hash(), 1, key, 2)
+	recordCommand(conn, &names, "QUEUED", "SET", "OtherKey", "SomeValue")
+	recordCommand(conn, &names, []interface{}{int64(3), "OK"}, "EXEC")
+	cmd := New(conn)
+	var getResult int
+	var scriptResult int
+	var setResult string
+	errCmd := cmd.
+		Get(&getResult, key).
+		Multi().
+		RunScript(&scriptResult, script, []string{key}, 2).
+		Set(&setResult, "OtherKey", "SomeValue").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, names, []string{"GET", "SCRIPT", "MULTI", "EVALSHA", "SET", "EXEC"})
+	assert.Equal(t, getResult, 1)
+	assert.Equal(t, scriptResult, 3)
+	assert.Equal(t, setResult, "OK")
+}
+
+func TestRunScriptLoadError(t *testing.T) {
+	key := "SomeKey"
+	script := NewScript("return redis.call(")
+	conn := redigomock.NewConn()
+	conn.Command("SCRIPT", "LOAD", script.Src()).Expect(redis.Error("ERR Error compiling script"))
+	conn.Command("EVALSHA", script.Hash(), 1, key).Expect(redis.Error("NOSCRIPT No matching script. Please use EVAL."))
+	cmd := New(conn)
+	var scriptResult int
+	errCmd := cmd.
+		RunScript(&scriptResult, script, []string{key}).
+		Commit()
+	assert.Contains(t, errCmd.Error(), "ERR Error compiling script")
+}
+
+func TestScriptRegistryLoad(t *testing.T) {
+	script1 := NewScript("return 1")
+	script2 := NewScript("return 2")
+	registry := NewScriptRegistry(script1)
+	registry.Register(script2)
+	conn := redigomock.NewConn()
+	load1 := conn.Command("SCRIPT", "LOAD", script1.Src()).Expect(script1.Hash())
+	load2 := conn.Command("SCRIPT", "LOAD", script2.Src()).Expect(script2.Hash())
+	errLoad := registry.Load(conn)
+	assert.Nil(t, errLoad)
+	assert.Equal(t, conn.Stats(load1), 1)
+	assert.Equal(t, conn.Stats(load2), 1)
+}
+
+func TestPublish(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("PUBLISH", "SomeChannel", "SomeMessage").Expect(int64(1))
+	cmd := New(conn)
+	var publishResult int
+	errCmd := cmd.Publish(&publishResult, "SomeChannel", "SomeMessage").Commit()
+
+	assert.Nil(t, errCmd)
+	assert.Equal(t, publishResult, 1)
+}
+
+func TestLPopWithCount(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("LPOP", key, uint64(2)).ExpectStringSlice("a", "b")
+	cmd := New(conn)
+	var popResult []string
+	errCmd := cmd.
+		LPop(&popResult, key, LPopOptionCount{Count: 2}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, popResult, []string{"a", "b"})
+}
+
+func TestLPosWithOptions(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("LPOS", key, "a", "RANK", int64(-1), "COUNT", uint64(0), "MAXLEN", uint64(10)).Expect([]interface{}{int64(3), int64(0)})
+	cmd := New(conn)
+	var posResult []int
+	errCmd := cmd.
+		LPos(&posResult, key, "a", LPosOptionRank{Rank: -1}, LPosOptionCount{Count: 0}, LPosOptionMaxLen{MaxLen: 10}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, posResult, []int{3, 0})
+}
+
+func TestLMove(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("LMOVE", "SomeSource", "SomeDestination", "LEFT", "RIGHT").Expect([]byte("a"))
+	cmd := New(conn)
+	var moveResult string
+	errCmd := cmd.
+		LMove(&moveResult, "SomeSource", "SomeDestination", ListDirectionLeft, ListDirectionRight).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, moveResult, "a")
+}
+
+// timeoutConn records the read timeout of the pipeline
+type timeoutConn struct {
+	*redigomock.Conn
+	timeout time.Duration
+}
+
+func (tc *timeoutConn) DoWithTimeout(timeout time.Duration, commandName string, args ...interface{}) (interface{}, error) {
+	tc.timeout = timeout
+	return tc.Conn.Do(commandName, args...)
+}
+
+func TestBLPop(t *testing.T) {
+	conn := &timeoutConn{Conn: redigomock.NewConn()}
+	conn.Command("BLPOP", "SomeKey", "OtherKey", float64(2)).Expect([]interface{}{[]byte("OtherKey"), []byte("a")})
+	cmd := New(conn)
+	var popResult ListElement
+	errCmd := cmd.
+		BLPop(&popResult, 2*time.Second, "SomeKey", "OtherKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, popResult, ListElement{Key: "OtherKey", Element: "a"})
+	assert.Equal(t, conn.timeout, 2*time.Second+blockReadTimeout)
+}
+
+func TestBLPopTimeout(t *testing.T) {
+	conn := &timeoutConn{Conn: redigomock.NewConn()}
+	conn.Command("BRPOP", "SomeKey", float64(0)).Expect(nil)
+	cmd := New(conn)
+	var popResult ListElement
+	errCmd := cmd.
+		BRPop(&popResult, 0, "SomeKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, popResult, ListElement{Timeout: true})
+	assert.Equal(t, conn.timeout, time.Duration(0))
+}
+
+func TestBLMPop(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("BLMPOP", float64(1), 2, "SomeKey", "OtherKey", "RIGHT", "COUNT", uint64(2)).
+		Expect([]interface{}{[]byte("SomeKey"), []interface{}{[]byte("c"), []byte("b")}})
+	cmd := New(conn)
+	var popResult ListElements
+	errCmd := cmd.
+		BLMPop(&popResult, time.Second, ListDirectionRight, []string{"SomeKey", "OtherKey"}, BLMPopOptionCount{Count: 2}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, popResult, ListElements{Key: "SomeKey", Elements: []string{"c", "b"}})
+}
+
+func TestBZPopMin(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("BZPOPMIN", "SomeKey", float64(0.5)).Expect([]interface{}{[]byte("SomeKey"), []byte("a"), []byte("1.5")})
+	cmd := New(conn)
+	var popResult ZElement
+	errCmd := cmd.
+		BZPopMin(&popResult, 500*time.Millisecond, "SomeKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, popResult, ZElement{Key: "SomeKey", Member: "a", Score: 1.5})
+}
+
+func TestSInterCard(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("SINTERCARD", 2, "SomeKey", "OtherKey", "LIMIT", uint64(5)).Expect(int64(3))
+	cmd := New(conn)
+	var cardResult int
+	errCmd := cmd.
+		SInterCard(&cardResult, []string{"SomeKey", "OtherKey"}, SInterCardOptionLimit{Limit: 5}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, cardResult, 3)
+}
+
+func TestZAddWithOptions(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("ZADD", key, "XX", "GT", "INCR", float64(2), "a").Expect([]byte("3"))
+	cmd := New(conn)
+	var zAddResult float64
+	errCmd := cmd.
+		ZAdd(&zAddResult, key, []float64{2}, []interface{}{"a"}, ZAddOptionXX{}, ZAddOptionGT{}, ZAddOptionINCR{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, zAddResult, float64(3))
+}
+
+func TestZAddLengthMismatch(t *testing.T) {
+	conn := redigomock.NewConn()
+	cmd := New(conn)
+	var zAddResult int
+	errCmd := cmd.
+		ZAdd(&zAddResult, "SomeKey", []float64{1, 2}, []interface{}{"a"}).
+		Commit()
+	assert.NotNil(t, errCmd)
+}
+
+func TestZRangeStore(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("ZRANGESTORE", "SomeDestination", "SomeKey", "(1", "+inf", "BYSCORE", "LIMIT", int64(0), int64(2)).Expect(int64(2))
+	cmd := New(conn)
+	var storeResult int
+	errCmd := cmd.
+		ZRangeStore(&storeResult, "SomeDestination", "SomeKey", "(1", "+inf", ZRangeOptionByScore{}, ZRangeOptionLimit{Offset: 0, Count: 2}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, storeResult, 2)
+}
+
+func TestZDiff(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("ZDIFF", 2, "SomeKey", "OtherKey", "WITHSCORES").ExpectStringSlice("a", "1")
+	cmd := New(conn)
+	var diffResult []string
+	errCmd := cmd.
+		ZDiff(&diffResult, []string{"SomeKey", "OtherKey"}, ZDiffOptionWithScores{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, diffResult, []string{"a", "1"})
+}
+
+func TestZRangeWithScoresMembers(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("ZRANGE", key, 0, -1, "WITHSCORES").ExpectStringSlice("a", "1", "b", "2.5")
+	cmd := New(conn)
+	var rangeResult []ZMember
+	errCmd := cmd.
+		ZRange(&rangeResult, key, 0, -1, ZRangeOptionWithScores{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, rangeResult, []ZMember{{Member: "a", Score: 1}, {Member: "b", Score: 2.5}})
+}
+
+func TestZRangeWithScoresErrorReply(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("ZRANGE", key, 0, -1, "WITHSCORES").Expect(redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	cmd := New(conn)
+	var rangeResult []ZMember
+	errCmd := cmd.
+		ZRange(&rangeResult, key, 0, -1, ZRangeOptionWithScores{}).
+		Commit()
+	assert.Contains(t, errCmd.Error(), "WRONGTYPE Operation against a key holding the wrong kind of value")
+	assert.NotContains(t, errCmd.Error(), "cannot convert")
+
+	var member ZMember
+	err := member.RedisScan(redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	assert.Equal(t, err, redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+}
+
+func TestZMembersNestedPairs(t *testing.T) {
+	var members []ZMember
+	err := (*zmembers)(&members).RedisScan([]interface{}{
+		[]interface{}{[]byte("a"), []byte("1")},
+		[]interface{}{[]byte("b"), float64(2.5)},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, members, []ZMember{{Member: "a", Score: 1}, {Member: "b", Score: 2.5}})
+
+	err = (*zmembers)(&members).RedisScan([]interface{}{[]byte("a")})
+	assert.NotNil(t, err)
+}
+
+func TestMGetMissingKeys(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("MGET", "SomeKey", "NotExistKey").Expect([]interface{}{[]byte("SomeValue"), nil})
+	cmd := New(conn)
+	var mGetResult []NullString
+	errCmd := cmd.
+		MGet(&mGetResult, "SomeKey", "NotExistKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, mGetResult, []NullString{{String: "SomeValue", Valid: true}, {}})
+}
+
+func TestMSetLengthMismatch(t *testing.T) {
+	conn := redigomock.NewConn()
+	cmd := New(conn)
+	var mSetResult string
+	errCmd := cmd.
+		MSet(&mSetResult, []string{"SomeKey", "OtherKey"}, []interface{}{"SomeValue"}).
+		Commit()
+	assert.NotNil(t, errCmd)
+}
+
+func TestSetWithGET(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("SET", key, "NewValue", "PXAT", uint64(1700000000000), "GET").Expect([]byte("OldValue"))
+	cmd := New(conn)
+	var setResult string
+	errCmd := cmd.
+		Set(&setResult, key, "NewValue", SetOptionPXAT{PXAT: 1700000000000}, SetOptionGET{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, setResult, "OldValue")
+}
+
+func TestBitField(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("BITFIELD", key, "SET", "u8", int64(0), int64(255), "GET", "i16", int64(8), "OVERFLOW", "FAIL", "INCRBY", "u8", int64(0), int64(1)).
+		Expect([]interface{}{int64(0), int64(-3), nil})
+	cmd := New(conn)
+	var bitFieldResult []*int64
+	errCmd := cmd.
+		BitField(&bitFieldResult, key, NewBitField().
+			Set(BitFieldUnsigned(8), 0, 255).
+			Get(BitFieldSigned(16), 8).
+			Overflow(BitFieldOverflowFail).
+			IncrBy(BitFieldUnsigned(8), 0, 1)).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, len(bitFieldResult), 3)
+	assert.Equal(t, *bitFieldResult[0], int64(0))
+	assert.Equal(t, *bitFieldResult[1], int64(-3))
+	assert.Nil(t, bitFieldResult[2])
+}
+
+func TestBitCountWithUnit(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("BITCOUNT", key, int64(1), int64(5), "BIT").Expect(int64(2))
+	cmd := New(conn)
+	var bitCountResult int
+	errCmd := cmd.
+		BitCount(&bitCountResult, key, BitCountOptionRange{Start: 1, End: 5, Unit: BitUnitBit}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, bitCountResult, 2)
+}
+
+func TestGeoSearchWithOptions(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("GEOSEARCH", key, "FROMLONLAT", float64(15), float64(37), "BYBOX", float64(400), float64(400), "km",
+		"ASC", "COUNT", uint64(1), "ANY", "WITHCOORD", "WITHDIST", "WITHHASH").
+		Expect([]interface{}{
+			[]interface{}{[]byte("Palermo"), []byte("190.4424"), int64(3479099956230698), []interface{}{[]byte("13.36"), []byte("38.11")}},
+		})
+	cmd := New(conn)
+	var searchResult []GeoLocation
+	errCmd := cmd.
+		GeoSearch(&searchResult, key, GeoFromLonLat{Longitude: 15, Latitude: 37}, GeoByBox{Width: 400, Height: 400, Unit: GeoUnitKilometers},
+			GeoSearchOptionAsc{}, GeoSearchOptionCount{Count: 1, Any: true},
+			GeoSearchOptionWithCoord{}, GeoSearchOptionWithDist{}, GeoSearchOptionWithHash{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, searchResult, []GeoLocation{
+		{Name: "Palermo", Longitude: 13.36, Latitude: 38.11, Dist: 190.4424, GeoHash: 3479099956230698},
+	})
+}
+
+func TestGeoSearchStore(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("GEOSEARCHSTORE", "SomeDestination", "SomeKey", "FROMMEMBER", "Palermo", "BYRADIUS", float64(200), "km", "DESC", "STOREDIST").
+		Expect(int64(2))
+	cmd := New(conn)
+	var storeResult int
+	errCmd := cmd.
+		GeoSearchStore(&storeResult, "SomeDestination", "SomeKey", GeoFromMember{Member: "Palermo"}, GeoByRadius{Radius: 200, Unit: GeoUnitKilometers},
+			GeoSearchOptionDesc{}, GeoSearchStoreOptionStoreDist{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, storeResult, 2)
+}
+
+func TestGeoAddWithOptions(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("GEOADD", "SomeKey", "XX", "CH", float64(12.5), float64(41.9), "Rome").Expect(int64(1))
+	cmd := New(conn)
+	var geoAddResult int
+	errCmd := cmd.
+		GeoAdd(&geoAddResult, "SomeKey", []GeoLocation{{Name: "Rome", Longitude: 12.5, Latitude: 41.9}}, GeoAddOptionXX{}, GeoAddOptionCH{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, geoAddResult, 1)
+}
+
+func TestTTL(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("TTL", "SomeKey").Expect(int64(10))
+	conn.Command("PTTL", "NoExpiryKey").Expect(int64(-1))
+	conn.Command("TTL", "NotExistKey").Expect(int64(-2))
+	cmd := New(conn)
+	var ttlResult time.Duration
+	var pttlNoExpiryResult time.Duration
+	var ttlMissingResult time.Duration
+	errCmd := cmd.
+		TTL(&ttlResult, "SomeKey").
+		PTTL(&pttlNoExpiryResult, "NoExpiryKey").
+		TTL(&ttlMissingResult, "NotExistKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, ttlResult, 10*time.Second)
+	assert.Equal(t, pttlNoExpiryResult, TTLNoExpiry)
+	assert.Equal(t, ttlMissingResult, TTLMissingKey)
+}
+
+func TestExpireWithOptions(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("PEXPIREAT", "SomeKey", int64(1700000000000), "NX").Expect(int64(1))
+	conn.Command("EXPIRE", "SomeKey", 10, "GT").Expect(int64(0))
+	cmd := New(conn)
+	var pExpireAtResult bool
+	var expireResult bool
+	errCmd := cmd.
+		PExpireAt(&pExpireAtResult, "SomeKey", 1700000000000, ExpireOptionNX{}).
+		Expire(&expireResult, "SomeKey", 10, ExpireOptionGT{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.True(t, pExpireAtResult)
+	assert.False(t, expireResult)
+}
+
+func TestObjectEncoding(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("OBJECT", "ENCODING", "SomeKey").Expect([]byte("embstr"))
+	cmd := New(conn)
+	var encodingResult string
+	errCmd := cmd.
+		ObjectEncoding(&encodingResult, "SomeKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, encodingResult, "embstr")
+}
+
+func TestHGetAllMap(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("HGETALL", "SomeKey").ExpectStringSlice("field1", "value1", "field2", "value2")
+	conn.Command("HGETALL", "NotExistKey").ExpectStringSlice()
+	cmd := New(conn)
+	var hGetAllResult map[string]string
+	var hGetAllMissingResult map[string]string
+	errCmd := cmd.
+		HGetAll(&hGetAllResult, "SomeKey").
+		HGetAll(&hGetAllMissingResult, "NotExistKey").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, hGetAllResult, map[string]string{"field1": "value1", "field2": "value2"})
+	assert.Equal(t, hGetAllMissingResult, map[string]string{})
+}
+
+func TestHRandFieldWithValues(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("HRANDFIELD", "SomeKey", int64(-2), "WITHVALUES").ExpectStringSlice("field1", "value1", "field1", "value1")
+	cmd := New(conn)
+	var hRandFieldResult []string
+	errCmd := cmd.
+		HRandField(&hRandFieldResult, "SomeKey", HRandFieldOptionCount{Count: -2, WithValues: true}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, hRandFieldResult, []string{"field1", "value1", "field1", "value1"})
+}
+
+func TestHSetLengthMismatch(t *testing.T) {
+	conn := redigomock.NewConn()
+	cmd := New(conn)
+	var hSetResult int
+	errCmd := cmd.
+		HSet(&hSetResult, "SomeKey", []string{"field1", "field2"}, []interface{}{"value1"}).
+		Commit()
+	assert.NotNil(t, errCmd)
+	assert.Equal(t, conn.Stats(conn.Command("HSET")), 0)
+}
+
+func TestHSetStruct(t *testing.T) {
+	type user struct {
+		Name    string `redis:"name"`
+		Age     int    `redis:"age"`
+		Admin   bool   `redis:"admin"`
+		Ignored string `redis:"-"`
+	}
+	conn := redigomock.NewConn()
+	conn.Command("HSET", "SomeKey", "name", "SomeName", "age", 0, "admin", false).Expect(int64(3))
+	conn.Command("HSET", "OtherKey", "name", "SomeName").Expect(int64(1))
+	cmd := New(conn)
+	var hSetResult int
+	var hSetOmitZeroResult int
+	errCmd := cmd.
+		HSetStruct(&hSetResult, "SomeKey", user{Name: "SomeName", Ignored: "SomeValue"}).
+		HSetStruct(&hSetOmitZeroResult, "OtherKey", &user{Name: "SomeName"}, HSetStructOptionOmitZero{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, hSetResult, 3)
+	assert.Equal(t, hSetOmitZeroResult, 1)
+}
+
+func TestHSetStructInvalid(t *testing.T) {
+	type user struct {
+		Name string `redis:"name"`
+	}
+	var hSetResult int
+	errNotStruct := New(redigomock.NewConn()).
+		HSetStruct(&hSetResult, "SomeKey", "SomeValue").
+		Commit()
+	errNoFields := New(redigomock.NewConn()).
+		HSetStruct(&hSetResult, "SomeKey", user{}, HSetStructOptionOmitZero{}).
+		Commit()
+	assert.NotNil(t, errNotStruct)
+	assert.NotNil(t, errNoFields)
+}
+
+func TestXTrimOptions(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("XTRIM", "SomeStream", "MAXLEN", "~", uint64(1000), "LIMIT", uint64(100)).Expect(int64(0))
+	conn.Command("XADD", "SomeStream", "NOMKSTREAM", "MINID", "~", "1-0", "LIMIT", uint64(10), "*", "Key", "SomeValue").Expect([]byte("2-0"))
+	cmd := New(conn)
+	var xtrimResult int
+	var xaddResult string
+	errCmd := cmd.
+		XTrim(&xtrimResult, "SomeStream", XTrimOptionMaxLen{MaxLen: 1000, Approximate: true}, XTrimOptionLimit{Limit: 100}).
+		XAdd(&xaddResult, "SomeStream", "*", []string{"Key", "SomeValue"}, XAddOptionNoMkStream{}, XAddOptionMinID{MinID: "1-0", Approximate: true}, XAddOptionLimit{Limit: 10}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, xtrimResult, 0)
+	assert.Equal(t, xaddResult, "2-0")
+}
+
+func TestXInfoStreamFull(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("XINFO", "STREAM", "SomeStream", "FULL", "COUNT", uint64(10)).Expect([]interface{}{[]byte("length"), int64(0)})
+	conn.Command("XAUTOCLAIM", "SomeStream", "SomeGroup", "SomeConsumer", uint64(100), "0-0", "COUNT", uint64(5), "JUSTID").
+		Expect([]interface{}{[]byte("0-0"), []interface{}{[]byte("1-0")}})
+	cmd := New(conn)
+	var xinfoResult []interface{}
+	var xautoclaimResult []interface{}
+	errCmd := cmd.
+		XInfoStream(&xinfoResult, "SomeStream", XInfoStreamOptionFull{Count: 10}).
+		XAutoClaim(&xautoclaimResult, "SomeStream", "SomeGroup", "SomeConsumer", 100, "0-0", XAutoClaimOptionCount{Count: 5}, XAutoClaimOptionJustID{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Len(t, xinfoResult, 2)
+	assert.Len(t, xautoclaimResult, 2)
+}
+
+func TestXGroupSetID(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("XGROUP", "SETID", "SomeStream", "SomeGroup", "$").Expect("OK")
+	cmd := New(conn)
+	var xgroupSetIDResult string
+	errCmd := cmd.
+		XGroupSetID(&xgroupSetIDResult, "SomeStream", "SomeGroup", "$").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, xgroupSetIDResult, "OK")
+}
+
+func TestXMessageDeletedEntries(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("XCLAIM", "SomeStream", "SomeGroup", "SomeConsumer", uint64(0), "1-0", "2-0").
+		Expect([]interface{}{nil, []interface{}{[]byte("2-0"), []interface{}{[]byte("Key"), []byte("SomeValue")}}})
+	conn.Command("XAUTOCLAIM", "SomeStream", "SomeGroup", "SomeConsumer", uint64(0), "0-0").
+		Expect([]interface{}{[]byte("0-0"), []interface{}{[]interface{}{[]byte("2-0"), nil}}, []interface{}{[]byte("1-0")}})
+	cmd := New(conn)
+	var xclaimResult []XMessage
+	var xautoclaimResult XAutoClaimResult
+	errCmd := cmd.
+		XClaim(&xclaimResult, "SomeStream", "SomeGroup", "SomeConsumer", 0, []string{"1-0", "2-0"}).
+		XAutoClaim(&xautoclaimResult, "SomeStream", "SomeGroup", "SomeConsumer", 0, "0-0").
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, xclaimResult, []XMessage{{}, {ID: "2-0", Values: map[string]string{"Key": "SomeValue"}}})
+	assert.Equal(t, xautoclaimResult, XAutoClaimResult{Next: "0-0", Messages: []XMessage{{ID: "2-0"}}, Deleted: []string{"1-0"}})
+}
+
+func TestXStreamInvalidReply(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("XREAD", "STREAMS", "SomeStream", "0").Expect([]interface{}{[]interface{}{[]byte("SomeStream")}})
+	cmd := New(conn)
+	var xreadResult []XStream
+	errCmd := cmd.
+		XRead(&xreadResult, []string{"SomeStream"}, []string{"0"}).
+		Commit()
+	assert.NotNil(t, errCmd)
+}
+
+func TestXAutoClaimResultScan(t *testing.T) {
+	var xautoclaimResult XAutoClaimResult
+	errExtra := xautoclaimResult.RedisScan([]interface{}{[]byte("0-0"), []interface{}{}, []interface{}{}, []byte("SomeNewField")})
+	assert.Nil(t, errExtra)
+	assert.Equal(t, xautoclaimResult.Next, "0-0")
+	errReply := xautoclaimResult.RedisScan(redis.Error("NOGROUP No such key"))
+	assert.Equal(t, errReply, redis.Error("NOGROUP No such key"))
+}
+
+func TestXMessageScanReset(t *testing.T) {
+	message := XMessage{ID: "1-0", Values: map[string]string{"Key": "SomeValue"}}
+	errNil := message.RedisScan(nil)
+	assert.Nil(t, errNil)
+	assert.Equal(t, message, XMessage{})
+	errReply := message.RedisScan(redis.Error("ERR SomeError"))
+	assert.Equal(t, errReply, redis.Error("ERR SomeError"))
+}
+
+func TestBlockingPopErrorReply(t *testing.T) {
+	wrongType := redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value")
+	var listElement ListElement
+	var listElements ListElements
+	var zElement ZElement
+	assert.Equal(t, listElement.RedisScan(wrongType), wrongType)
+	assert.Equal(t, listElements.RedisScan(wrongType), wrongType)
+	assert.Equal(t, zElement.RedisScan(wrongType), wrongType)
+}
+
+func TestMultiCommitContext(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	conn.Command("MULTI").Expect("OK")
+	conn.Command("INCR", key).Expect("QUEUED")
+	exec := conn.Command("EXEC").ExpectSlice(int64(1))
+	cmd := New(conn)
+	var incrResult int64
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	errCmd := cmd.
+		Multi().
+		Incr(&incrResult, key).
+		CommitContext(ctx)
+	assert.Nil(t, errCmd)
+	assert.True(t, exec.Called)
+	assert.Equal(t, incrResult, int64(1))
+}
+
+func TestCommitVariantsRelease(t *testing.T) {
+	key := "SomeKey"
+	conn := redigomock.NewConn()
+	closed := 0
+	conn.CloseMock = func() error {
+		closed++
+		return nil
+	}
+	conn.Command("GET", key).Expect([]byte("1"))
+	conn.Command("MULTI").Expect("OK")
+	conn.Command("DISCARD").Expect("OK")
+	var getResult int
+	cmd := New(conn)
+	_, errReport := cmd.
+		Get(&getResult, key).
+		CommitReport()
+	assert.Nil(t, errReport)
+	assert.Equal(t, closed, 1)
+	_, errReportMulti := cmd.
+		Multi().
+		Get(&getResult, key).
+		CommitReport()
+	assert.NotNil(t, errReportMulti)
+	assert.Equal(t, closed, 2)
+	errContext := cmd.
+		Get(&getResult, key).
+		CommitContext(context.Background())
+	assert.Nil(t, errContext)
+	assert.Equal(t, closed, 3)
+	errNothing := cmd.CommitContext(context.Background())
+	assert.Nil(t, errNothing)
+	assert.Equal(t, closed, 4)
+}
+
+func TestGeoDefaultUnit(t *testing.T) {
+	conn := redigomock.NewConn()
+	conn.Command("GEOSEARCH", "SomeKey", "FROMMEMBER", "Palermo", "BYRADIUS", float64(5), "m").ExpectStringSlice("Palermo")
+	conn.Command("GEOSEARCH", "SomeKey", "FROMMEMBER", "Palermo", "BYBOX", float64(5), float64(10), "m").ExpectStringSlice("Palermo")
+	conn.Command("GEODIST", "SomeKey", "Palermo", "Catania", "m").Expect([]byte("166274.1516"))
+	cmd := New(conn)
+	var radiusResult []string
+	var boxResult []string
+	var distResult float64
+	errCmd := cmd.
+		GeoSearch(&radiusResult, "SomeKey", GeoFromMember{Member: "Palermo"}, GeoByRadius{Radius: 5}).
+		GeoSearch(&boxResult, "SomeKey", GeoFromMember{Member: "Palermo"}, GeoByBox{Width: 5, Height: 10}).
+		GeoDist(&distResult, "SomeKey", "Palermo", "Catania", GeoDistOptionUnit{}).
+		Commit()
+	assert.Nil(t, errCmd)
+	assert.Equal(t, radiusResult, []string{"Palermo"})
+	assert.Equal(t, boxResult, []string{"Palermo"})
+	assert.Equal(t, distResult, 166274.1516)
+}
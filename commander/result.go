@@ -0,0 +1,473 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ListElement is the result of BLPOP, BRPOP and BLMOVE.
+type ListElement struct {
+	// Key is the key of the list which the element is popped from, it is empty for BLMOVE
+	Key string
+	// Element is the popped element
+	Element string
+	// Timeout is set when no element is popped before the timeout
+	Timeout bool
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (le *ListElement) RedisScan(src interface{}) error {
+	*le = ListElement{}
+	switch src := src.(type) {
+	case redis.Error:
+		return src
+	case nil:
+		le.Timeout = true
+		return nil
+	case []byte:
+		le.Element = string(src)
+		return nil
+	case []interface{}:
+		_, err := redis.Scan(src, &le.Key, &le.Element)
+		return err
+	}
+	return fmt.Errorf("bluto: cannot convert from %T to ListElement", src)
+}
+
+// ListElements is the result of BLMPOP.
+type ListElements struct {
+	// Key is the key of the list which the elements are popped from
+	Key string
+	// Elements are the popped elements
+	Elements []string
+	// Timeout is set when no element is popped before the timeout
+	Timeout bool
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (le *ListElements) RedisScan(src interface{}) error {
+	*le = ListElements{}
+	switch src := src.(type) {
+	case redis.Error:
+		return src
+	case nil:
+		le.Timeout = true
+		return nil
+	case []interface{}:
+		_, err := redis.Scan(src, &le.Key, &le.Elements)
+		return err
+	}
+	return fmt.Errorf("bluto: cannot convert from %T to ListElements", src)
+}
+
+// ZElement is the result of BZPOPMIN and BZPOPMAX.
+type ZElement struct {
+	// Key is the key of the sorted set which the member is popped from
+	Key string
+	// Member is the popped member
+	Member string
+	// Score is the score of the popped member
+	Score float64
+	// Timeout is set when no member is popped before the timeout
+	Timeout bool
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (ze *ZElement) RedisScan(src interface{}) error {
+	*ze = ZElement{}
+	switch src := src.(type) {
+	case redis.Error:
+		return src
+	case nil:
+		ze.Timeout = true
+		return nil
+	case []interface{}:
+		_, err := redis.Scan(src, &ze.Key, &ze.Member, &ze.Score)
+		return err
+	}
+	return fmt.Errorf("bluto: cannot convert from %T to ZElement", src)
+}
+
+// ZMember is a member of a sorted set with its score, it is the result of the WITHSCORES replies as *[]ZMember.
+type ZMember struct {
+	Member string
+	Score  float64
+}
+
+// RedisScan satisfies redis.Scanner interface, the src is a [member, score] pair.
+func (zm *ZMember) RedisScan(src interface{}) error {
+	if err, ok := src.(redis.Error); ok {
+		return err
+	}
+	pair, ok := src.([]interface{})
+	if !ok {
+		return fmt.Errorf("bluto: cannot convert from %T to ZMember", src)
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("bluto: cannot convert a reply of %d elements to ZMember", len(pair))
+	}
+	member, err := redis.String(pair[0], nil)
+	if err != nil {
+		return err
+	}
+	// the score of RESP3 is a double
+	score, ok := pair[1].(float64)
+	if !ok {
+		score, err = redis.Float64(pair[1], nil)
+		if err != nil {
+			return err
+		}
+	}
+	zm.Member = member
+	zm.Score = score
+	return nil
+}
+
+// zmembers scans the flat array of alternating members and scores, or the array of
+// [member, score] pairs of RESP3, into the ZMember slice
+type zmembers []ZMember
+
+// RedisScan satisfies redis.Scanner interface.
+func (zms *zmembers) RedisScan(src interface{}) error {
+	if err, ok := src.(redis.Error); ok {
+		return err
+	}
+	if src == nil {
+		*zms = nil
+		return nil
+	}
+	values, ok := src.([]interface{})
+	if !ok {
+		return fmt.Errorf("bluto: cannot convert from %T to []ZMember", src)
+	}
+	members := make([]ZMember, 0, len(values)/2)
+	for i := 0; i < len(values); i++ {
+		var member ZMember
+		if pair, ok := values[i].([]interface{}); ok {
+			err := member.RedisScan(pair)
+			if err != nil {
+				return err
+			}
+		} else {
+			if i+1 >= len(values) {
+				return errors.New("bluto: the member has no score")
+			}
+			err := member.RedisScan(values[i : i+2])
+			if err != nil {
+				return err
+			}
+			i++
+		}
+		members = append(members, member)
+	}
+	*zms = members
+	return nil
+}
+
+// zmembersResult returns the scanner of the result if it is a *[]ZMember
+func zmembersResult(result interface{}) interface{} {
+	if members, ok := result.(*[]ZMember); ok {
+		return (*zmembers)(members)
+	}
+	return result
+}
+
+// NullString is a string value which may be nil, it reports the missing keys of MGET as *[]NullString.
+type NullString struct {
+	String string
+	// Valid is set if the value is not nil
+	Valid bool
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (ns *NullString) RedisScan(src interface{}) error {
+	*ns = NullString{}
+	if src == nil {
+		return nil
+	}
+	value, err := redis.String(src, nil)
+	if err != nil {
+		return err
+	}
+	ns.String = value
+	ns.Valid = true
+	return nil
+}
+
+// GeoLocation is a member of a geospatial index, it is the result of GEOSEARCH as *[]GeoLocation and of GEOPOS.
+type GeoLocation struct {
+	// Name is the member
+	Name string
+	// Longitude and Latitude are the coordinates of the member, they are set by GEOPOS and WITHCOORD
+	Longitude float64
+	Latitude  float64
+	// Dist is the distance from the center of the search, it is set by WITHDIST
+	Dist float64
+	// GeoHash is the geohash of the member as an integer, it is set by WITHHASH
+	GeoHash int64
+}
+
+// RedisScan satisfies redis.Scanner interface, the src is the member or the member with the
+// distance, the geohash and the coordinates which are returned by the WITH options in order.
+func (gl *GeoLocation) RedisScan(src interface{}) error {
+	*gl = GeoLocation{}
+	switch src := src.(type) {
+	case []byte:
+		gl.Name = string(src)
+		return nil
+	case []interface{}:
+		if len(src) == 0 {
+			return errors.New("bluto: cannot convert an empty reply to GeoLocation")
+		}
+		name, err := redis.String(src[0], nil)
+		if err != nil {
+			return err
+		}
+		gl.Name = name
+		// the type of each value shows which of the WITH options it belongs to
+		for _, value := range src[1:] {
+			switch value := value.(type) {
+			case []byte:
+				gl.Dist, err = redis.Float64(value, nil)
+			case int64:
+				gl.GeoHash = value
+			case []interface{}:
+				err = gl.scanCoord(value)
+			default:
+				err = fmt.Errorf("bluto: cannot convert from %T to GeoLocation", value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("bluto: cannot convert from %T to GeoLocation", src)
+}
+
+// scanCoord scans the [longitude, latitude] pair
+func (gl *GeoLocation) scanCoord(coord []interface{}) error {
+	if len(coord) != 2 {
+		return fmt.Errorf("bluto: cannot convert a reply of %d elements to coordinates", len(coord))
+	}
+	_, err := redis.Scan(coord, &gl.Longitude, &gl.Latitude)
+	return err
+}
+
+// geoPositions scans the positions of GEOPOS into the locations of the members
+type geoPositions struct {
+	result  *[]*GeoLocation
+	members []string
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (gp *geoPositions) RedisScan(src interface{}) error {
+	positions, err := redis.Values(src, nil)
+	if err != nil {
+		return err
+	}
+	if len(positions) != len(gp.members) {
+		return errors.New("bluto: the number of positions and members of GEOPOS are not equal")
+	}
+	locations := make([]*GeoLocation, len(positions))
+	for index, position := range positions {
+		if position == nil {
+			continue
+		}
+		coord, err := redis.Values(position, nil)
+		if err != nil {
+			return err
+		}
+		location := &GeoLocation{Name: gp.members[index]}
+		err = location.scanCoord(coord)
+		if err != nil {
+			return err
+		}
+		locations[index] = location
+	}
+	*gp.result = locations
+	return nil
+}
+
+const (
+	// TTLNoExpiry is the result of TTL and PTTL for a key which exists but has no expiry.
+	TTLNoExpiry time.Duration = -1
+	// TTLMissingKey is the result of TTL and PTTL for a key which does not exist.
+	TTLMissingKey time.Duration = -2
+)
+
+// ttlResult scans the reply of TTL and PTTL in the unit into the duration
+type ttlResult struct {
+	result *time.Duration
+	unit   time.Duration
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (tr *ttlResult) RedisScan(src interface{}) error {
+	ttl, err := redis.Int64(src, nil)
+	if err != nil {
+		return err
+	}
+	switch ttl {
+	case -1:
+		*tr.result = TTLNoExpiry
+	case -2:
+		*tr.result = TTLMissingKey
+	default:
+		*tr.result = time.Duration(ttl) * tr.unit
+	}
+	return nil
+}
+
+// stringMap scans the alternating fields and values into the map
+type stringMap map[string]string
+
+// RedisScan satisfies redis.Scanner interface.
+func (sm *stringMap) RedisScan(src interface{}) error {
+	values, err := redis.StringMap(src, nil)
+	if err != nil {
+		return err
+	}
+	*sm = values
+	return nil
+}
+
+// stringMapResult returns the scanner of the result if it is a *map[string]string
+func stringMapResult(result interface{}) interface{} {
+	if values, ok := result.(*map[string]string); ok {
+		return (*stringMap)(values)
+	}
+	return result
+}
+
+// structResult scans the alternating fields and values into the struct by redis.ScanStruct
+type structResult struct {
+	v interface{}
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (sr *structResult) RedisScan(src interface{}) error {
+	values, err := redis.Values(src, nil)
+	if err != nil {
+		return err
+	}
+	return redis.ScanStruct(values, sr.v)
+}
+
+// XMessage is an entry of a stream, it is the result of XRANGE, XREVRANGE and XCLAIM as *[]XMessage.
+// Only the ID is set for the JUSTID replies, and only the ID or nothing is set for the deleted entries
+// which are still pending.
+type XMessage struct {
+	ID     string
+	Values map[string]string
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (xm *XMessage) RedisScan(src interface{}) error {
+	*xm = XMessage{}
+	switch src := src.(type) {
+	case redis.Error:
+		return src
+	case nil:
+		return nil
+	case []byte:
+		xm.ID = string(src)
+		return nil
+	case []interface{}:
+		if len(src) != 2 {
+			return fmt.Errorf("bluto: cannot convert a reply of %d elements to XMessage", len(src))
+		}
+		id, err := redis.String(src[0], nil)
+		if err != nil {
+			return err
+		}
+		xm.ID = id
+		if src[1] == nil {
+			return nil
+		}
+		values, err := redis.StringMap(src[1], nil)
+		if err != nil {
+			return err
+		}
+		xm.Values = values
+		return nil
+	}
+	return fmt.Errorf("bluto: cannot convert from %T to XMessage", src)
+}
+
+// ScanStruct scans the values of the message into the struct pointed by v, the fields are matched by their redis tags.
+func (xm XMessage) ScanStruct(v interface{}) error {
+	values := make([]interface{}, 0, 2*len(xm.Values))
+	for field, value := range xm.Values {
+		values = append(values, []byte(field), []byte(value))
+	}
+	return redis.ScanStruct(values, v)
+}
+
+// XStream is a stream with its entries, it is the result of XREAD and XREADGROUP as *[]XStream.
+type XStream struct {
+	Name     string
+	Messages []XMessage
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (xs *XStream) RedisScan(src interface{}) error {
+	*xs = XStream{}
+	if err, ok := src.(redis.Error); ok {
+		return err
+	}
+	stream, ok := src.([]interface{})
+	if !ok {
+		return fmt.Errorf("bluto: cannot convert from %T to XStream", src)
+	}
+	if len(stream) != 2 {
+		return fmt.Errorf("bluto: cannot convert a reply of %d elements to XStream", len(stream))
+	}
+	var messages []XMessage
+	_, err := redis.Scan(stream, &xs.Name, &messages)
+	if err != nil {
+		return err
+	}
+	xs.Messages = messages
+	return nil
+}
+
+// XAutoClaimResult is the result of XAUTOCLAIM, Next is the start ID of the next call and Deleted are the
+// IDs of the deleted entries which are removed from the pending entries list (Redis>=7.0).
+type XAutoClaimResult struct {
+	Next     string
+	Messages []XMessage
+	Deleted  []string
+}
+
+// RedisScan satisfies redis.Scanner interface.
+func (xr *XAutoClaimResult) RedisScan(src interface{}) error {
+	*xr = XAutoClaimResult{}
+	if err, ok := src.(redis.Error); ok {
+		return err
+	}
+	reply, ok := src.([]interface{})
+	if !ok {
+		return fmt.Errorf("bluto: cannot convert from %T to XAutoClaimResult", src)
+	}
+	if len(reply) < 2 {
+		return fmt.Errorf("bluto: cannot convert a reply of %d elements to XAutoClaimResult", len(reply))
+	}
+	var messages []XMessage
+	var deleted []string
+	dest := []interface{}{&xr.Next, &messages, &deleted}
+	// the elements which are added by the later versions of the server are ignored
+	if len(reply) > len(dest) {
+		reply = reply[:len(dest)]
+	}
+	_, err := redis.Scan(reply, dest[:len(reply)]...)
+	if err != nil {
+		return err
+	}
+	xr.Messages = messages
+	xr.Deleted = deleted
+	return nil
+}
@@ -0,0 +1,185 @@
+package commander
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Script is a lua script which is run with EVALSHA by its SHA1 digest.
+type Script struct {
+	src  string
+	hash string
+}
+
+// NewScript returns a new script with the given lua source.
+func NewScript(src string) *Script {
+	digest := sha1.Sum([]byte(src))
+	return &Script{
+		src:  src,
+		hash: hex.EncodeToString(digest[:]),
+	}
+}
+
+// Src returns the lua source of the script.
+func (s *Script) Src() string {
+	return s.src
+}
+
+// Hash returns the SHA1 digest of the script source.
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// scriptArgs returns the arguments of EVAL and EVALSHA, spec is either the source or the digest of the script.
+func scriptArgs(spec string, keys []string, args []interface{}) redis.Args {
+	cmd := redis.Args{}.Add(spec).Add(len(keys))
+	for _, key := range keys {
+		cmd = cmd.Add(key)
+	}
+	return cmd.Add(args...)
+}
+
+// scriptLoad is a SCRIPT LOAD which is queued ahead of the first run of a script by the commander.
+type scriptLoad struct {
+	script *Script
+	// index is the position of the first run of the script in the chain
+	index int
+	// position is the position of the SCRIPT LOAD reply in the replies of the connection, it is -1
+	// for the scripts of a transaction block until they are sent before MULTI
+	position int
+}
+
+// isNoScript reports whether the reply is the NOSCRIPT error of a script which is not loaded.
+func isNoScript(reply interface{}) bool {
+	replyErr, ok := reply.(redis.Error)
+	return ok && strings.HasPrefix(string(replyErr), "NOSCRIPT")
+}
+
+// loadScript queues SCRIPT LOAD of the script ahead of its first run by the commander, so that its EVALSHA
+// never fails with NOSCRIPT. The scripts of a transaction block are loaded before MULTI by Exec.
+func (c *Commander) loadScript(script *Script) {
+	if c.loaded[script.hash] {
+		return
+	}
+	for _, load := range c.loads {
+		if load.script.hash == script.hash {
+			return
+		}
+	}
+	load := scriptLoad{
+		script:   script,
+		index:    len(c.pendingResults),
+		position: -1,
+	}
+	if !c.multi {
+		load.position = len(c.pendingResults) + len(c.loads)
+		c.err = c.conn.Send("SCRIPT", "LOAD", script.src)
+	}
+	c.loads = append(c.loads, load)
+}
+
+// sendBlockLoads sends SCRIPT LOAD of the scripts of the transaction block before MULTI.
+func (c *Commander) sendBlockLoads() error {
+	sent := 0
+	for _, load := range c.loads {
+		if load.position >= 0 {
+			sent++
+		}
+	}
+	for i := range c.loads {
+		if c.loads[i].position >= 0 {
+			continue
+		}
+		c.loads[i].position = c.multiIndex + sent
+		sent++
+		err := c.conn.Send("SCRIPT", "LOAD", c.loads[i].script.src)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropLoads removes the SCRIPT LOAD replies from the replies of the chain and marks the loaded scripts,
+// the error of a failed load is returned as the reply of its script when it is not in the transaction block.
+func (c *Commander) dropLoads(results []interface{}) []interface{} {
+	if len(c.loads) == 0 {
+		return results
+	}
+	loadReplies := make([]interface{}, len(c.loads))
+	kept := make([]interface{}, 0, len(results))
+	next := 0
+	for position, reply := range results {
+		if next < len(c.loads) && c.loads[next].position == position {
+			loadReplies[next] = reply
+			next++
+			continue
+		}
+		kept = append(kept, reply)
+	}
+	if c.loaded == nil {
+		c.loaded = make(map[string]bool)
+	}
+	for i, load := range c.loads {
+		replyErr, ok := loadReplies[i].(redis.Error)
+		if !ok {
+			c.loaded[load.script.hash] = true
+			continue
+		}
+		inBlock := c.multi && load.index >= c.multiIndex
+		if !inBlock && load.index < len(kept) && isNoScript(kept[load.index]) {
+			kept[load.index] = replyErr
+		}
+	}
+	return kept
+}
+
+// ScriptRegistry holds the scripts which are loaded on every new connection of bluto pool.
+type ScriptRegistry struct {
+	mu      sync.RWMutex
+	scripts []*Script
+}
+
+// NewScriptRegistry returns a new registry with the given scripts.
+func NewScriptRegistry(scripts ...*Script) *ScriptRegistry {
+	return &ScriptRegistry{
+		scripts: scripts,
+	}
+}
+
+// Register adds the script to the registry and returns it.
+func (r *ScriptRegistry) Register(script *Script) *Script {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scripts = append(r.scripts, script)
+	return script
+}
+
+// Load loads all the registered scripts on the connection with SCRIPT LOAD.
+func (r *ScriptRegistry) Load(conn redis.Conn) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.scripts) == 0 {
+		return nil
+	}
+	for _, script := range r.scripts {
+		err := conn.Send("SCRIPT", "LOAD", script.src)
+		if err != nil {
+			return err
+		}
+	}
+	results, err := redis.Values(conn.Do(""))
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if replyErr, ok := result.(redis.Error); ok {
+			return replyErr
+		}
+	}
+	return nil
+}
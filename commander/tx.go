@@ -0,0 +1,54 @@
+package commander
+
+import (
+	"github.com/gomodule/redigo/redis"
+)
+
+// Tx is an optimistic transaction over a single redis connection which is used by bluto Watch
+type Tx struct {
+	conn redis.Conn
+}
+
+// NewTx returns a new transaction, the commanders of the transaction keep the connection
+// open and it is only returned to the pool by Close
+func NewTx(conn redis.Conn) *Tx {
+	return &Tx{
+		conn: conn,
+	}
+}
+
+// txConn is a connection which is not closed by the commanders of a transaction
+type txConn struct {
+	redis.Conn
+}
+
+// Close satisfies redis.Conn interface without closing the connection.
+func (tc txConn) Close() error {
+	return nil
+}
+
+// Watch marks the given keys to be watched for conditional execution of the transaction.
+func (tx *Tx) Watch(keys ...string) error {
+	iKeys := make([]interface{}, len(keys))
+	for i := range keys {
+		iKeys[i] = keys[i]
+	}
+	_, err := tx.conn.Do("WATCH", iKeys...)
+	return err
+}
+
+// Read returns a commander for the read phase of the transaction.
+func (tx *Tx) Read() *Commander {
+	return New(txConn{tx.conn})
+}
+
+// Multi returns a commander for the write phase of the transaction, its commands are
+// executed by Exec only if none of the watched keys has been changed.
+func (tx *Tx) Multi() *Commander {
+	return New(txConn{tx.conn}).Multi()
+}
+
+// Close returns the connection to the pool, the keys are unwatched if the transaction is not executed.
+func (tx *Tx) Close() error {
+	return tx.conn.Close()
+}
@@ -181,3 +181,39 @@ func Example_scanner() {
 
 	// Output: SomeKey
 }
+
+func Example_xstream() {
+	bluto, _ := bluto.New(bluto.Config{
+		Address:               "localhost:6379",
+		ConnectTimeoutSeconds: 10,
+		ReadTimeoutSeconds:    10,
+	})
+	defer bluto.ClosePool()
+
+	groupName := "testGroup"
+	consumerName := "testConsumer"
+	var flushResult string
+	var xgroupCreateResult string
+	var xaddResult string
+	var xreadgroupResult []commander.XStream
+
+	err := bluto.Borrow().
+		FlushAll(&flushResult).
+		XGroupCreate(&xgroupCreateResult, "testStream", groupName, "0-0", commander.XGroupCreateOptionMKStream{}).
+		XAdd(&xaddResult, "testStream", "*", &Fields{Key: "SomeKey"}).
+		XReadGroup(&xreadgroupResult, groupName, consumerName, []string{"testStream"}, []string{">"}).
+		Commit()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	message := xreadgroupResult[0].Messages[0]
+	var fields Fields
+	err = message.ScanStruct(&fields)
+	if err != nil {
+		log.Panic(err)
+	}
+	fmt.Println(message.Values["Key"], fields.Key)
+
+	// Output: SomeKey SomeKey
+}